@@ -289,8 +289,8 @@ func ExtractAttrs(err error) AttrList {
 
 		// If this is a carrier with classifications, add them to the queue
 		// This ensures we traverse all attached attributed errors
-		if c, ok := current.(*carrier); ok {
-			for _, cls := range c.classifications {
+		if c, ok := current.(interface{ classificationList() []Classified }); ok {
+			for _, cls := range c.classificationList() {
 				queue = append(queue, cls)
 			}
 		}
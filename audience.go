@@ -0,0 +1,100 @@
+package errx
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Audience identifies who a display message is shown to. Higher values are more
+// privileged: a message for an audience may also be shown to any more privileged one.
+// Custom audiences can be defined between or above the predefined ones.
+type Audience int
+
+// Predefined audiences, from least to most privileged.
+const (
+	// AudiencePublic is anonymous users of a public API
+	AudiencePublic Audience = iota * 10
+
+	// AudienceCustomer is authenticated users, who may see details about their own data
+	AudienceCustomer
+
+	// AudienceSupport is internal support staff, who may see operational details
+	AudienceSupport
+)
+
+// String returns the name of a predefined audience, or its number.
+func (a Audience) String() string {
+	switch a {
+	case AudiencePublic:
+		return "public"
+	case AudienceCustomer:
+		return "customer"
+	case AudienceSupport:
+		return "support"
+	}
+	return "audience(" + strconv.Itoa(int(a)) + ")"
+}
+
+// NewDisplayableFor creates a displayable error with messages for several audiences.
+// message is shown to every audience without a more specific message, and is what
+// DisplayText returns. texts adds messages for specific audiences; DisplayTextFor
+// picks the message of the most privileged audience not above the requested one.
+//
+// Example:
+//
+//	var ErrPaymentDeclined = errx.NewDisplayableFor("Payment failed", map[errx.Audience]string{
+//	    errx.AudienceCustomer: "Your card was declined",
+//	    errx.AudienceSupport:  "Card declined by issuer (insufficient funds)",
+//	})
+//
+//	errx.DisplayTextFor(err, errx.AudiencePublic)  // "Payment failed"
+//	errx.DisplayTextFor(err, errx.AudienceSupport) // "Card declined by issuer (insufficient funds)"
+func NewDisplayableFor(message string, texts map[Audience]string) Classified {
+	d := &displayable{
+		sentinel: &sentinel{text: message},
+	}
+	if len(texts) > 0 {
+		d.texts = make(map[Audience]string, len(texts))
+		for audience, text := range texts {
+			d.texts[audience] = text
+		}
+	}
+	return d
+}
+
+// textFor returns the message of the most privileged audience not above audience,
+// falling back to the default message.
+func (d *displayable) textFor(audience Audience) string {
+	text := d.text
+	best, found := Audience(0), false
+	for a, t := range d.texts {
+		if a <= audience && (!found || a > best) {
+			best, found, text = a, true, t
+		}
+	}
+	return text
+}
+
+// DisplayTextFor is like DisplayText, but returns the message of the displayable
+// error intended for the given audience. Messages for more privileged audiences are
+// never returned; if the displayable error has no message for the audience or a less
+// privileged one, its default message is returned. Displayable errors created with
+// NewDisplayable have the same message for every audience.
+//
+// If no displayable error is found, it returns the full error message, like DisplayText.
+//
+// Example:
+//
+//	msg := errx.DisplayTextFor(err, errx.AudienceCustomer)
+func DisplayTextFor(err error, audience Audience) string {
+	if err == nil {
+		return ""
+	}
+
+	var dErr *displayable
+	if errors.As(err, &dErr) {
+		return dErr.textFor(audience)
+	}
+
+	return err.Error()
+}
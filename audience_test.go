@@ -0,0 +1,87 @@
+package errx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx"
+)
+
+func TestDisplayTextFor(t *testing.T) {
+	declined := errx.NewDisplayableFor("Payment failed", map[errx.Audience]string{
+		errx.AudienceCustomer: "Your card was declined",
+		errx.AudienceSupport:  "Card declined by issuer (insufficient funds)",
+	})
+	supportOnly := errx.NewDisplayableFor("Something went wrong", map[errx.Audience]string{
+		errx.AudienceSupport: "Ledger replica lagging",
+	})
+	err := errx.Wrap("charge order", errx.Classify(errors.New("issuer: 51"), declined))
+
+	tests := []struct {
+		name     string
+		err      error
+		audience errx.Audience
+		want     string
+	}{
+		{"public", err, errx.AudiencePublic, "Payment failed"},
+		{"customer", err, errx.AudienceCustomer, "Your card was declined"},
+		{"support", err, errx.AudienceSupport, "Card declined by issuer (insufficient funds)"},
+		{"custom between falls back", err, errx.AudienceCustomer + 5, "Your card was declined"},
+		{"custom above", err, errx.AudienceSupport + 10, "Card declined by issuer (insufficient funds)"},
+		{"below public", err, errx.AudiencePublic - 1, "Payment failed"},
+		{"customer falls back to default", supportOnly, errx.AudienceCustomer, "Something went wrong"},
+		{"support only", supportOnly, errx.AudienceSupport, "Ledger replica lagging"},
+		{"plain displayable", errx.NewDisplayable("Not found"), errx.AudienceSupport, "Not found"},
+		{"not displayable", errors.New("internal"), errx.AudienceCustomer, "internal"},
+		{"nil", nil, errx.AudienceSupport, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errx.DisplayTextFor(tt.err, tt.audience); got != tt.want {
+				t.Errorf("DisplayTextFor(%v) = %q, want %q", tt.audience, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewDisplayableFor_DefaultMessage(t *testing.T) {
+	d := errx.NewDisplayableFor("Payment failed", map[errx.Audience]string{errx.AudienceSupport: "detail"})
+	err := errx.Wrap("charge order", errors.New("issuer: 51"), d)
+
+	if !errx.IsDisplayable(err) {
+		t.Error("IsDisplayable() = false, want true")
+	}
+	if got := errx.DisplayText(err); got != "Payment failed" {
+		t.Errorf("DisplayText() = %q, want the default message", got)
+	}
+	if got := d.Error(); got != "Payment failed" {
+		t.Errorf("Error() = %q, want the default message", got)
+	}
+	if len(errx.Sentinels(err)) != 0 {
+		t.Errorf("Sentinels() = %v, want none", errx.Sentinels(err))
+	}
+}
+
+func TestNewDisplayableFor_CopiesTexts(t *testing.T) {
+	texts := map[errx.Audience]string{errx.AudienceSupport: "detail"}
+	d := errx.NewDisplayableFor("Payment failed", texts)
+	texts[errx.AudienceSupport] = "changed"
+
+	if got := errx.DisplayTextFor(d, errx.AudienceSupport); got != "detail" {
+		t.Errorf("DisplayTextFor() = %q, want %q", got, "detail")
+	}
+}
+
+func TestAudience_String(t *testing.T) {
+	for audience, want := range map[errx.Audience]string{
+		errx.AudiencePublic:   "public",
+		errx.AudienceCustomer: "customer",
+		errx.AudienceSupport:  "support",
+		errx.Audience(15):     "audience(15)",
+	} {
+		if got := audience.String(); got != want {
+			t.Errorf("Audience(%d).String() = %q, want %q", int(audience), got, want)
+		}
+	}
+}
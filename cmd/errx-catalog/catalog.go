@@ -0,0 +1,278 @@
+package main
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+const (
+	errxPath   = "github.com/go-extras/errx"
+	compatPath = "github.com/go-extras/errx/compat"
+)
+
+// Entry kinds.
+const (
+	KindSentinel    = "sentinel"
+	KindDisplayable = "displayable"
+)
+
+// Catalog lists the sentinels and displayable errors declared in a set of packages.
+type Catalog struct {
+	Entries   []*Entry    `json:"entries"`
+	Hierarchy []*TreeNode `json:"hierarchy"`
+}
+
+// Entry is a package-level sentinel or displayable error declaration.
+type Entry struct {
+	// ID is the import path and variable name, such as "example.com/shop.ErrNotFound"
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Package  string `json:"package"`
+	Kind     string `json:"kind"`
+	Text     string `json:"text"`
+	Doc      string `json:"doc,omitempty"`
+	Position string `json:"position"`
+
+	// Parents are the IDs of parent sentinels. Parents that are not package-level
+	// variables are recorded as their source expression.
+	Parents []string `json:"parents,omitempty"`
+
+	// Severity is the default severity declared with errx.Severity, such as "WARN"
+	Severity string `json:"severity,omitempty"`
+
+	// Audiences maps audience names, such as "support", to the messages declared
+	// for them with errx.NewDisplayableFor
+	Audiences map[string]string `json:"audiences,omitempty"`
+
+	// Children are the IDs of cataloged sentinels declaring this one as a parent
+	Children []string `json:"children,omitempty"`
+}
+
+// TreeNode is a sentinel in the hierarchy tree. A sentinel with several parents
+// appears under each of them.
+type TreeNode struct {
+	ID       string      `json:"id"`
+	Children []*TreeNode `json:"children,omitempty"`
+}
+
+// collect builds the catalog of the given packages.
+func collect(pkgs []*srcload.Package) *Catalog {
+	c := &Catalog{}
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.VAR {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					c.Entries = append(c.Entries, collectSpec(pkg, file, gen, spec.(*ast.ValueSpec))...)
+				}
+			}
+		}
+	}
+	c.link()
+	return c
+}
+
+// collectSpec returns the entries declared by a single var spec.
+func collectSpec(pkg *srcload.Package, file *ast.File, gen *ast.GenDecl, spec *ast.ValueSpec) []*Entry {
+	var entries []*Entry
+	for i, name := range spec.Names {
+		if i >= len(spec.Values) || name.Name == "_" {
+			break
+		}
+		call, ok := ast.Unparen(spec.Values[i]).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		var kind string
+		switch path, fn := pkg.Callee(file, call); {
+		case path == errxPath && fn == "NewSentinel", path == compatPath && fn == "NewSentinel":
+			kind = KindSentinel
+		case path == errxPath && (fn == "NewDisplayable" || fn == "NewDisplayableFor"):
+			kind = KindDisplayable
+		default:
+			continue
+		}
+		if len(call.Args) == 0 {
+			continue
+		}
+
+		entry := &Entry{
+			ID:       pkg.Path + "." + name.Name,
+			Name:     name.Name,
+			Package:  pkg.Path,
+			Kind:     kind,
+			Text:     stringValue(pkg, call.Args[0]),
+			Doc:      docText(gen, spec),
+			Position: position(pkg.Fset, name.Pos()),
+		}
+		if kind == KindDisplayable {
+			if len(call.Args) > 1 {
+				entry.Audiences = audienceTexts(pkg, call.Args[1])
+			}
+			entries = append(entries, entry)
+			continue
+		}
+		for _, arg := range call.Args[1:] {
+			if sev, ok := ast.Unparen(arg).(*ast.CallExpr); ok && len(sev.Args) == 1 {
+				if path, fn := pkg.Callee(file, sev); path == errxPath && fn == "Severity" {
+					entry.Severity = severityValue(pkg, sev.Args[0])
+					continue
+				}
+			}
+			entry.Parents = append(entry.Parents, reference(pkg, file, arg))
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// severityValue returns the name of a constant slog level, or the source of the
+// expression if it is not a constant.
+func severityValue(pkg *srcload.Package, expr ast.Expr) string {
+	if tv, ok := pkg.Info.Types[expr]; ok && tv.Value != nil {
+		if v, exact := constant.Int64Val(constant.ToInt(tv.Value)); exact {
+			return slog.Level(v).String()
+		}
+	}
+	return types.ExprString(expr)
+}
+
+// audienceTexts returns the messages of a map literal passed to errx.NewDisplayableFor,
+// keyed by audience name. Audiences that are not constants are keyed by their source.
+func audienceTexts(pkg *srcload.Package, expr ast.Expr) map[string]string {
+	lit, ok := ast.Unparen(expr).(*ast.CompositeLit)
+	if !ok || len(lit.Elts) == 0 {
+		return nil
+	}
+	texts := make(map[string]string, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		audience := types.ExprString(kv.Key)
+		if tv, ok := pkg.Info.Types[kv.Key]; ok && tv.Value != nil {
+			if v, exact := constant.Int64Val(constant.ToInt(tv.Value)); exact {
+				audience = errx.Audience(v).String()
+			}
+		}
+		texts[audience] = stringValue(pkg, kv.Value)
+	}
+	return texts
+}
+
+// stringValue returns the value of a string expression, or its source if it is
+// not a constant.
+func stringValue(pkg *srcload.Package, expr ast.Expr) string {
+	if tv, ok := pkg.Info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value)
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		if s, err := strconv.Unquote(lit.Value); err == nil {
+			return s
+		}
+	}
+	return types.ExprString(expr)
+}
+
+// reference returns the ID of the package-level variable referenced by expr,
+// or its source if it is not one.
+func reference(pkg *srcload.Package, file *ast.File, expr ast.Expr) string {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		if v, ok := pkg.Info.Uses[e].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+			return v.Pkg().Path() + "." + v.Name()
+		}
+		if _, ok := pkg.Info.Uses[e]; !ok {
+			return pkg.Path + "." + e.Name
+		}
+	case *ast.SelectorExpr:
+		if v, ok := pkg.Info.Uses[e.Sel].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() {
+			return v.Pkg().Path() + "." + v.Name()
+		}
+		if x, ok := e.X.(*ast.Ident); ok {
+			if path := srcload.ImportedAs(file, x.Name); path != "" {
+				return path + "." + e.Sel.Name
+			}
+		}
+	}
+	return types.ExprString(expr)
+}
+
+// docText returns the doc comment of a var spec, falling back to the declaration's
+// doc comment for ungrouped declarations and to the trailing line comment.
+func docText(gen *ast.GenDecl, spec *ast.ValueSpec) string {
+	doc := spec.Doc
+	if doc == nil && !gen.Lparen.IsValid() {
+		doc = gen.Doc
+	}
+	if doc == nil {
+		doc = spec.Comment
+	}
+	return strings.TrimSpace(doc.Text())
+}
+
+// position formats a position as file:line, with the file relative to the
+// working directory where possible.
+func position(fset *token.FileSet, pos token.Pos) string {
+	p := fset.Position(pos)
+	file := p.Filename
+	if wd, err := os.Getwd(); err == nil && filepath.IsAbs(file) {
+		if rel, err := filepath.Rel(wd, file); err == nil && !strings.HasPrefix(rel, "..") {
+			file = rel
+		}
+	}
+	return filepath.ToSlash(file) + ":" + strconv.Itoa(p.Line)
+}
+
+// link fills in the children of each entry and builds the hierarchy tree.
+func (c *Catalog) link() {
+	byID := make(map[string]*Entry, len(c.Entries))
+	for _, e := range c.Entries {
+		byID[e.ID] = e
+	}
+
+	isChild := make(map[string]bool)
+	for _, e := range c.Entries {
+		for _, parent := range e.Parents {
+			if p, ok := byID[parent]; ok {
+				p.Children = append(p.Children, e.ID)
+				isChild[e.ID] = true
+			}
+		}
+	}
+
+	for _, e := range c.Entries {
+		if e.Kind == KindSentinel && !isChild[e.ID] {
+			c.Hierarchy = append(c.Hierarchy, tree(byID, e, map[string]bool{}))
+		}
+	}
+}
+
+// tree builds the hierarchy below an entry. path holds the entries above it, so
+// that cyclic declarations terminate.
+func tree(byID map[string]*Entry, e *Entry, path map[string]bool) *TreeNode {
+	node := &TreeNode{ID: e.ID}
+	path[e.ID] = true
+	for _, child := range e.Children {
+		if !path[child] {
+			node.Children = append(node.Children, tree(byID, byID[child], path))
+		}
+	}
+	delete(path, e.ID)
+	return node
+}
@@ -0,0 +1,85 @@
+// Errx-catalog generates a catalog of the error conditions declared by Go packages.
+//
+// It finds package-level variables initialized with errx.NewSentinel,
+// compat.NewSentinel, errx.NewDisplayable and errx.NewDisplayableFor, and reports
+// their text, audience messages, parents, children, severity and doc comments,
+// together with the sentinel hierarchy. Packages are parsed and type-checked from
+// source using only the standard library.
+//
+// Usage:
+//
+//	errx-catalog [flags] [packages]
+//
+// Packages are directories; a trailing "/..." includes all directories below.
+// The default is the current directory.
+//
+// The flags are:
+//
+//	-format markdown|json
+//	    Output format (default markdown).
+//	-o file
+//	    Write the catalog to file instead of standard output.
+//	-title text
+//	    Title of the Markdown document (default "Error Catalog").
+//
+// Example:
+//
+//	//go:generate errx-catalog -o ERRORS.md ./...
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "errx-catalog:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run executes the command with the given arguments.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("errx-catalog", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	format := flags.String("format", "markdown", "output format: markdown or json")
+	output := flags.String("o", "", "write the catalog to `file` instead of standard output")
+	title := flags.String("title", "Error Catalog", "title of the Markdown document")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *format != "markdown" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	pkgs, err := srcload.Load(flags.Args()...)
+	if err != nil {
+		return err
+	}
+	catalog := collect(pkgs)
+
+	var buf bytes.Buffer
+	if *format == "json" {
+		err = renderJSON(&buf, catalog)
+	} else {
+		err = renderMarkdown(&buf, catalog, *title)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(buf.Bytes())
+		return err
+	}
+	return os.WriteFile(*output, buf.Bytes(), 0o644) //nolint:gosec // the catalog is documentation
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const shopPath = "github.com/go-extras/errx/cmd/errx-catalog/testdata/shop"
+
+func TestRun_Markdown(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"./testdata/shop"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v, stderr: %s", err, stderr.String())
+	}
+	out := stdout.String()
+
+	for _, want := range []string{
+		"# Error Catalog\n",
+		"## Hierarchy\n\n- `shop.ErrNotFound` - not found\n  - `shop.ErrProductNotFound` - product not found\n  - `shop.ErrMissingFile` - missing file\n- `shop.ErrConflict` - conflict\n",
+		"## Package `" + shopPath + "`\n",
+		"### ErrNotFound\n\nErrNotFound is returned when a resource does not exist.\n",
+		"- Children: `shop.ErrProductNotFound`, `shop.ErrMissingFile`\n",
+		"### ErrConflict\n\nReturned on concurrent modification.\n",
+		"- Text: `conflict`\n- Severity: WARN\n",
+		"- Parents: `fs.ErrNotExist`, `shop.ErrNotFound`\n",
+		"- Kind: displayable\n- Text: `This product is out of stock`\n",
+		"- Kind: displayable\n- Text: `Payment failed`\n- Text for customer: `Your card was declined`\n- Text for support: `Declined by the card issuer`\n",
+		"- Declared at: `testdata/shop/errors.go:16`\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"local", "errUnrelated"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("expected %q not to be cataloged, got:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestRun_JSON(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-format", "json", "./testdata/..."}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	var catalog Catalog
+	if err := json.Unmarshal(stdout.Bytes(), &catalog); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(catalog.Entries) != 6 {
+		t.Fatalf("expected 6 entries, got %d", len(catalog.Entries))
+	}
+
+	missing := catalog.Entries[3]
+	if missing.ID != shopPath+".ErrMissingFile" || missing.Kind != KindSentinel {
+		t.Errorf("unexpected entry %+v", missing)
+	}
+	if len(missing.Parents) != 2 || missing.Parents[0] != "io/fs.ErrNotExist" || missing.Parents[1] != shopPath+".ErrNotFound" {
+		t.Errorf("unexpected parents %v", missing.Parents)
+	}
+
+	declined := catalog.Entries[5]
+	if declined.Kind != KindDisplayable || declined.Audiences["support"] != "Declined by the card issuer" || len(declined.Parents) != 0 {
+		t.Errorf("unexpected entry %+v", declined)
+	}
+
+	conflict := catalog.Entries[2]
+	if conflict.Severity != "WARN" || len(conflict.Parents) != 0 {
+		t.Errorf("unexpected entry %+v", conflict)
+	}
+
+	if len(catalog.Hierarchy) != 2 || len(catalog.Hierarchy[0].Children) != 2 {
+		t.Fatalf("unexpected hierarchy %+v", catalog.Hierarchy)
+	}
+	if catalog.Hierarchy[0].Children[0].ID != shopPath+".ErrProductNotFound" {
+		t.Errorf("unexpected hierarchy child %q", catalog.Hierarchy[0].Children[0].ID)
+	}
+}
+
+func TestRun_OutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "ERRORS.md")
+
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-o", output, "-title", "Shop Errors", "./testdata/shop"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if stdout.Len() != 0 {
+		t.Errorf("expected no standard output, got %q", stdout.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# Shop Errors\n") {
+		t.Errorf("unexpected catalog:\n%s", data)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-format", "yaml", "./testdata/shop"}, &stdout, &stderr); err == nil {
+		t.Error("expected error for unknown format")
+	}
+	if err := run([]string{"./testdata/missing"}, &stdout, &stderr); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestRun_Empty(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{t.TempDir()}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "No sentinels or displayable errors found.") {
+		t.Errorf("unexpected output:\n%s", stdout.String())
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// renderJSON writes the catalog as indented JSON.
+func renderJSON(w io.Writer, c *Catalog) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(c)
+}
+
+// renderMarkdown writes the catalog as a Markdown document: the sentinel hierarchy,
+// followed by a section per package describing each declaration.
+func renderMarkdown(w io.Writer, c *Catalog, title string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n", title)
+
+	if len(c.Entries) == 0 {
+		b.WriteString("\nNo sentinels or displayable errors found.\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	byID := make(map[string]*Entry, len(c.Entries))
+	for _, e := range c.Entries {
+		byID[e.ID] = e
+	}
+
+	if len(c.Hierarchy) > 0 {
+		b.WriteString("\n## Hierarchy\n\n")
+		for _, node := range c.Hierarchy {
+			writeTreeNode(&b, byID, node, "")
+		}
+	}
+
+	pkg := ""
+	for _, e := range c.Entries {
+		if e.Package != pkg {
+			pkg = e.Package
+			fmt.Fprintf(&b, "\n## Package `%s`\n", pkg)
+		}
+		writeEntry(&b, e)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTreeNode writes a hierarchy node and its children as a nested list.
+func writeTreeNode(b *strings.Builder, byID map[string]*Entry, node *TreeNode, indent string) {
+	e := byID[node.ID]
+	fmt.Fprintf(b, "%s- `%s` - %s\n", indent, shortName(e.ID), e.Text)
+	for _, child := range node.Children {
+		writeTreeNode(b, byID, child, indent+"  ")
+	}
+}
+
+// writeEntry writes the section describing a single declaration.
+func writeEntry(b *strings.Builder, e *Entry) {
+	fmt.Fprintf(b, "\n### %s\n\n", e.Name)
+	if e.Doc != "" {
+		b.WriteString(e.Doc)
+		b.WriteString("\n\n")
+	}
+	fmt.Fprintf(b, "- Kind: %s\n", e.Kind)
+	fmt.Fprintf(b, "- Text: `%s`\n", e.Text)
+	for _, audience := range sortedKeys(e.Audiences) {
+		fmt.Fprintf(b, "- Text for %s: `%s`\n", audience, e.Audiences[audience])
+	}
+	if len(e.Parents) > 0 {
+		fmt.Fprintf(b, "- Parents: %s\n", codeList(e.Parents))
+	}
+	if len(e.Children) > 0 {
+		fmt.Fprintf(b, "- Children: %s\n", codeList(e.Children))
+	}
+	if e.Severity != "" {
+		fmt.Fprintf(b, "- Severity: %s\n", e.Severity)
+	}
+	fmt.Fprintf(b, "- Declared at: `%s`\n", e.Position)
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// codeList formats IDs as a comma-separated list of short names in code spans.
+func codeList(ids []string) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = "`" + shortName(id) + "`"
+	}
+	return strings.Join(names, ", ")
+}
+
+// shortName shortens an ID to package name and variable name,
+// such as "example.com/shop.ErrNotFound" to "shop.ErrNotFound".
+// Source expressions are returned unchanged.
+func shortName(id string) string {
+	if strings.ContainsAny(id, "() ") {
+		return id
+	}
+	return id[strings.LastIndex(id, "/")+1:]
+}
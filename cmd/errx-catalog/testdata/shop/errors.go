@@ -0,0 +1,41 @@
+// Package shop is a fixture for errx-catalog tests.
+package shop
+
+import (
+	"io/fs"
+	"log/slog"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+)
+
+const conflictText = "conflict"
+
+var (
+	// ErrNotFound is returned when a resource does not exist.
+	ErrNotFound = errx.NewSentinel("not found")
+
+	// ErrProductNotFound is returned when a product does not exist.
+	ErrProductNotFound = errx.NewSentinel("product not found", ErrNotFound)
+
+	ErrConflict = errx.NewSentinel(conflictText, errx.Severity(slog.LevelWarn)) // Returned on concurrent modification.
+)
+
+// ErrMissingFile is a kind of fs.ErrNotExist.
+var ErrMissingFile = compat.NewSentinel("missing file", fs.ErrNotExist, ErrNotFound)
+
+// ErrOutOfStock is shown to customers when an order cannot be fulfilled.
+var ErrOutOfStock = errx.NewDisplayable("This product is out of stock")
+
+// ErrPaymentDeclined is shown when a payment is refused.
+var ErrPaymentDeclined = errx.NewDisplayableFor("Payment failed", map[errx.Audience]string{
+	errx.AudienceCustomer: "Your card was declined",
+	errx.AudienceSupport:  "Declined by the card issuer",
+})
+
+var errUnrelated = fs.ErrClosed
+
+func find() error {
+	local := errx.NewSentinel("local") // Not package-level, not cataloged
+	return local
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+// generator holds the data of the generated file.
+type generator struct {
+	Source  string
+	Spec    *Spec
+	Imports [][]string // Standard library imports, then others
+
+	// Specificity lists the errors from the most to the least specific
+	Specificity []*ErrorSpec
+}
+
+// generate returns the formatted Go source for a spec. source names the spec
+// file in the generated header.
+func generate(spec *Spec, source string) ([]byte, error) {
+	g := &generator{
+		Source:      source,
+		Spec:        spec,
+		Imports:     groupImports(append([]string{"errors", "github.com/go-extras/errx"}, spec.Imports...)),
+		Specificity: bySpecificity(spec.Errors),
+	}
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, g); err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// groupImports sorts and deduplicates import paths, grouping the standard library
+// (paths without a dot in their first element) before other packages.
+func groupImports(paths []string) [][]string {
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+
+	var std, other []string
+	for _, path := range paths {
+		first, _, _ := strings.Cut(path, "/")
+		if strings.Contains(first, ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	return slices.DeleteFunc([][]string{std, other}, func(group []string) bool { return len(group) == 0 })
+}
+
+// bySpecificity orders errors by decreasing depth in the hierarchy, keeping the
+// spec order for errors at the same depth.
+func bySpecificity(errs []*ErrorSpec) []*ErrorSpec {
+	codes := make(map[string]*ErrorSpec, len(errs))
+	for _, e := range errs {
+		codes[e.Code] = e
+	}
+
+	depths := make(map[string]int, len(errs))
+	var depth func(e *ErrorSpec) int
+	depth = func(e *ErrorSpec) int {
+		if d, ok := depths[e.Code]; ok {
+			return d
+		}
+		d := 0
+		for _, parent := range e.Parents {
+			d = max(d, depth(codes[parent])+1)
+		}
+		depths[e.Code] = d
+		return d
+	}
+
+	result := slices.Clone(errs)
+	slices.SortStableFunc(result, func(a, b *ErrorSpec) int {
+		return depth(b) - depth(a)
+	})
+	return result
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"param":    paramName,
+	"comment":  comment,
+	"parents":  func(e *ErrorSpec, s *Spec) []string { return parentNames(e, s) },
+	"hasAttrs": func(e *ErrorSpec) bool { return len(e.Attrs) > 0 },
+}).Parse(`// Code generated by errx-gen from {{.Source}}. DO NOT EDIT.
+
+package {{.Spec.Package}}
+
+import (
+{{- range $i, $group := .Imports}}
+{{- if $i}}
+{{end}}
+{{- range $group}}
+	{{printf "%q" .}}
+{{- end}}
+{{- end}}
+)
+
+// Error codes.
+const (
+{{- range $i, $e := .Spec.Errors}}
+{{- if $i}}
+{{end}}
+	// Code{{.Name}} is the code of Err{{.Name}}.
+	Code{{.Name}} = {{printf "%q" .Code}}
+{{- end}}
+)
+
+// Sentinels.
+var (
+{{- range $i, $e := .Spec.Errors}}
+{{- if $i}}
+{{end}}
+{{comment (printf "Err%s" .Name) .Doc .Code}}
+	Err{{.Name}} = errx.NewSentinel({{printf "%q" .Text}}{{range parents . $.Spec}}, {{.}}{{end}})
+{{- end}}
+)
+{{- $hasDisplay := false}}
+{{- range .Spec.Errors}}{{if .Display}}{{$hasDisplay = true}}{{end}}{{end}}
+{{- if $hasDisplay}}
+
+// Display messages, attached by the constructor helpers.
+var (
+{{- range .Spec.Errors}}{{if .Display}}
+	display{{.Name}} = errx.NewDisplayable({{printf "%q" .Display}})
+{{- end}}{{end}}
+)
+{{- end}}
+
+// Metadata describes an error code.
+type Metadata struct {
+	// Code identifies the error across services
+	Code string
+
+	// Display is the message safe to show to end users
+	Display string
+
+	// HTTPStatus is the HTTP status code to respond with, or 0
+	HTTPStatus int
+
+	// Retryable reports whether the failed operation may succeed when retried
+	Retryable bool
+
+	// DocsURL links to the documentation of the error
+	DocsURL string
+}
+
+// metadata holds the metadata of each sentinel.
+var metadata = map[errx.Classified]Metadata{
+{{- range .Spec.Errors}}
+	Err{{.Name}}: {
+		Code: Code{{.Name}},
+		{{- if .Display}}
+		Display: {{printf "%q" .Display}},
+		{{- end}}
+		{{- if .HTTPStatus}}
+		HTTPStatus: {{.HTTPStatus}},
+		{{- end}}
+		{{- if .Retryable}}
+		Retryable: true,
+		{{- end}}
+		{{- if .DocsURL}}
+		DocsURL: {{printf "%q" .DocsURL}},
+		{{- end}}
+	},
+{{- end}}
+}
+
+// specificity lists the sentinels from the most to the least specific,
+// so that MetadataOf finds the most specific sentinel first.
+var specificity = []errx.Classified{
+{{- range .Specificity}}
+	Err{{.Name}},
+{{- end}}
+}
+
+// MetadataOf returns the metadata of the most specific sentinel of this package
+// that err is classified with.
+func MetadataOf(err error) (Metadata, bool) {
+	for _, sentinel := range specificity {
+		if errors.Is(err, sentinel) {
+			return metadata[sentinel], true
+		}
+	}
+	return Metadata{}, false
+}
+{{- range .Spec.Errors}}
+{{- $e := .}}
+
+// New{{.Name}} returns a new error classified as Err{{.Name}}.
+func New{{.Name}}({{range $i, $a := .Attrs}}{{if $i}}, {{end}}{{param $a.Name}} {{$a.Type}}{{end}}) error {
+	return errx.ClassifyNew({{printf "%q" .Text}}, Err{{.Name}}{{if .Display}}, display{{.Name}}{{end}}, errx.Attrs("error_code", Code{{.Name}}{{range .Attrs}}, {{printf "%q" .Name}}, {{param .Name}}{{end}}))
+}
+
+// Wrap{{.Name}} wraps cause as Err{{.Name}}. It returns nil if cause is nil.
+func Wrap{{.Name}}(cause error{{range .Attrs}}, {{param .Name}} {{.Type}}{{end}}) error {
+	return errx.Wrap({{printf "%q" .Text}}, cause, Err{{.Name}}{{if .Display}}, display{{.Name}}{{end}}, errx.Attrs("error_code", Code{{.Name}}{{range .Attrs}}, {{printf "%q" .Name}}, {{param .Name}}{{end}}))
+}
+{{- end}}
+`))
+
+// parentNames returns the Go names of the parents of an error.
+func parentNames(e *ErrorSpec, s *Spec) []string {
+	names := make([]string, len(e.Parents))
+	for i, parent := range e.Parents {
+		for _, other := range s.Errors {
+			if other.Code == parent {
+				names[i] = "Err" + other.Name
+			}
+		}
+	}
+	return names
+}
+
+// comment formats the doc comment of a sentinel, falling back to a generic
+// description without doc.
+func comment(name, doc, code string) string {
+	doc = strings.TrimSpace(doc)
+	if !strings.HasPrefix(doc, name+" ") {
+		doc = strings.TrimSpace(fmt.Sprintf("%s is the sentinel of the %s error code.\n\n%s", name, code, doc))
+	}
+	lines := strings.Split(doc, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight("\t// "+strings.TrimSpace(line), " ")
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,4 @@
+// Package shoperr is an example of code generated by errx-gen, used by its tests.
+package shoperr
+
+//go:generate go run ../.. -o errors_gen.go errors.json
@@ -0,0 +1,65 @@
+package shoperr_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/cmd/errx-gen/internal/shoperr"
+	"github.com/go-extras/errx/errxtest"
+)
+
+func TestHierarchy(t *testing.T) {
+	errxtest.AssertIs(t, shoperr.NewProductNotFound("p-1"), shoperr.ErrProductNotFound, shoperr.ErrNotFound)
+	errxtest.AssertNotIs(t, shoperr.NewNotFound(), shoperr.ErrProductNotFound)
+}
+
+func TestConstructors(t *testing.T) {
+	err := shoperr.NewProductNotFound("p-1")
+	if err.Error() != "product not found" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	errxtest.AssertDisplayText(t, err, "This product does not exist.")
+	errxtest.AssertAttr(t, err, "error_code", shoperr.CodeProductNotFound)
+	errxtest.AssertAttr(t, err, "product_id", "p-1")
+
+	wrapped := shoperr.WrapUnavailable(errors.New("connection refused"), "inventory", time.Second)
+	if wrapped.Error() != "service unavailable: connection refused" {
+		t.Errorf("unexpected message %q", wrapped.Error())
+	}
+	errxtest.AssertIs(t, wrapped, shoperr.ErrUnavailable)
+	errxtest.AssertAttr(t, wrapped, "retry_after", time.Second)
+	if errx.IsDisplayable(wrapped) {
+		t.Error("expected no display text without display message")
+	}
+
+	if shoperr.WrapNotFound(nil) != nil {
+		t.Error("expected nil for nil cause")
+	}
+}
+
+func TestMetadataOf(t *testing.T) {
+	md, ok := shoperr.MetadataOf(errx.Wrap("lookup", shoperr.NewProductNotFound("p-1")))
+	if !ok {
+		t.Fatal("expected metadata")
+	}
+	want := shoperr.Metadata{
+		Code:       shoperr.CodeProductNotFound,
+		Display:    "This product does not exist.",
+		HTTPStatus: 404,
+		DocsURL:    "https://example.com/errors/product-not-found",
+	}
+	if md != want {
+		t.Errorf("MetadataOf() = %+v, want %+v", md, want)
+	}
+
+	md, ok = shoperr.MetadataOf(errx.Classify(errors.New("timeout"), shoperr.ErrUnavailable))
+	if !ok || md.Code != shoperr.CodeUnavailable || !md.Retryable || md.HTTPStatus != 503 {
+		t.Errorf("unexpected metadata %+v", md)
+	}
+
+	if _, ok := shoperr.MetadataOf(errors.New("other")); ok {
+		t.Error("expected no metadata for unclassified error")
+	}
+}
@@ -0,0 +1,94 @@
+// Errx-gen generates Go declarations for an error taxonomy described in a JSON
+// spec file, so that the taxonomy can be shared with services written in other
+// languages.
+//
+// For each error code of the spec, it generates:
+//   - a Code constant and an errx sentinel, with the declared parents
+//   - metadata (display message, HTTP status, retryability and documentation URL),
+//     looked up with the generated MetadataOf function
+//   - New and Wrap constructor helpers taking the declared typed attributes, which
+//     attach the sentinel, the display message and an "error_code" attribute
+//
+// Usage:
+//
+//	errx-gen [flags] spec.json
+//
+// The flags are:
+//
+//	-o file
+//	    Write the generated code to file instead of standard output.
+//
+// Example:
+//
+//	//go:generate errx-gen -o errors_gen.go errors.json
+//
+// A spec file looks like this:
+//
+//	{
+//	  "package": "shoperr",
+//	  "errors": [
+//	    {"code": "NOT_FOUND", "text": "not found", "http_status": 404},
+//	    {
+//	      "code": "PRODUCT_NOT_FOUND",
+//	      "text": "product not found",
+//	      "parents": ["NOT_FOUND"],
+//	      "display": "This product does not exist.",
+//	      "http_status": 404,
+//	      "docs_url": "https://example.com/errors/product-not-found",
+//	      "attrs": [{"name": "product_id", "type": "string"}]
+//	    }
+//	  ]
+//	}
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "errx-gen:", err)
+		}
+		os.Exit(2)
+	}
+}
+
+// run executes the command with the given arguments.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("errx-gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	output := flags.String("o", "", "write the generated code to `file` instead of standard output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("expected a single spec file")
+	}
+
+	path := flags.Arg(0)
+	data, err := os.ReadFile(path) //nolint:gosec // reading the spec file is the purpose
+	if err != nil {
+		return err
+	}
+	spec, err := parseSpec(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	src, err := generate(spec, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(src)
+		return err
+	}
+	return os.WriteFile(*output, src, 0o644) //nolint:gosec // generated source is not secret
+}
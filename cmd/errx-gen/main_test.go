@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_UpToDate(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"internal/shoperr/errors.json"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v, stderr: %s", err, stderr.String())
+	}
+
+	want, err := os.ReadFile("internal/shoperr/errors_gen.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != string(want) {
+		t.Errorf("generated code differs from internal/shoperr/errors_gen.go; run go generate ./...\n%s", stdout.String())
+	}
+}
+
+func TestRun_OutputFile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "errors_gen.go")
+
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{"-o", output, "internal/shoperr/errors.json"}, &stdout, &stderr); err != nil {
+		t.Fatalf("run() error = %v", err)
+	}
+	data, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "// Code generated by errx-gen from errors.json. DO NOT EDIT.\n") {
+		t.Errorf("unexpected generated code:\n%s", data)
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if err := run(nil, &stdout, &stderr); err == nil {
+		t.Error("expected error without spec file")
+	}
+	if err := run([]string{"missing.json"}, &stdout, &stderr); err == nil {
+		t.Error("expected error for missing spec file")
+	}
+}
+
+func TestParseSpec_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{"syntax", `{`, "parsing spec"},
+		{"unknown field", `{"package": "p", "errors": [{"code": "A", "text": "a", "status": 1}]}`, "unknown field"},
+		{"package", `{"package": "my-errors"}`, `invalid package name "my-errors"`},
+		{"missing code", `{"package": "p", "errors": [{"text": "a"}]}`, "error 0: missing code"},
+		{"duplicate code", `{"package": "p", "errors": [{"code": "A", "text": "a"}, {"code": "A", "text": "b"}]}`, "error A: duplicate code"},
+		{"missing text", `{"package": "p", "errors": [{"code": "A"}]}`, "error A: missing text"},
+		{"invalid name", `{"package": "p", "errors": [{"code": "A", "name": "lower", "text": "a"}]}`, `invalid name "lower"`},
+		{"duplicate name", `{"package": "p", "errors": [{"code": "NOT_FOUND", "text": "a"}, {"code": "not-found", "text": "b"}]}`, `name "NotFound" already used by NOT_FOUND`},
+		{"unknown parent", `{"package": "p", "errors": [{"code": "A", "text": "a", "parents": ["B"]}]}`, "error A: unknown parent B"},
+		{"cycle", `{"package": "p", "errors": [{"code": "A", "text": "a", "parents": ["B"]}, {"code": "B", "text": "b", "parents": ["A"]}]}`, "parent cycle: A -> B -> A"},
+		{"attr type", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "x", "type": "[["}]}]}`, `attribute x: invalid type "[["`},
+		{"attr name", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "cause", "type": "string"}]}]}`, `parameter name "cause" is reserved`},
+		{"attr duplicate", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "id", "type": "string"}, {"name": "ID", "type": "int"}]}]}`, `invalid or duplicate parameter name "id"`},
+		{"attr errx", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "errx", "type": "string"}]}]}`, `parameter name "errx" is reserved`},
+		{"attr errors", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "errors", "type": "int"}]}]}`, `parameter name "errors" is reserved`},
+		{"attr import", `{"package": "p", "imports": ["time"], "errors": [{"code": "A", "text": "a", "attrs": [{"name": "time", "type": "time.Time"}]}]}`, `parameter name "time" is reserved`},
+		{"attr versioned import", `{"package": "p", "imports": ["example.com/money/v2"], "errors": [{"code": "A", "text": "a", "attrs": [{"name": "money", "type": "money.Amount"}]}]}`, `parameter name "money" is reserved`},
+		{"attr display", `{"package": "p", "errors": [{"code": "A", "text": "a", "attrs": [{"name": "display_b", "type": "string"}]}, {"code": "B", "text": "b", "display": "B"}]}`, `parameter name "displayB" is reserved`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSpec([]byte(tt.spec))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("parseSpec() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, tt := range []struct{ in, exported, param string }{
+		{"PRODUCT_NOT_FOUND", "ProductNotFound", "productNotFound"},
+		{"product_id", "ProductID", "productID"},
+		{"docs-url", "DocsURL", "docsURL"},
+		{"retryAfter", "RetryAfter", "retryAfter"},
+		{"type", "Type", "typeValue"},
+	} {
+		if got := exportedName(tt.in); got != tt.exported {
+			t.Errorf("exportedName(%q) = %q, want %q", tt.in, got, tt.exported)
+		}
+		if got := paramName(tt.in); got != tt.param {
+			t.Errorf("paramName(%q) = %q, want %q", tt.in, got, tt.param)
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// Spec is an error taxonomy, as read from a JSON spec file.
+type Spec struct {
+	// Package is the name of the generated package
+	Package string `json:"package"`
+
+	// Imports are additional import paths needed by attribute types
+	Imports []string `json:"imports,omitempty"`
+
+	// Errors are the error codes of the taxonomy
+	Errors []*ErrorSpec `json:"errors"`
+}
+
+// ErrorSpec describes a single error code.
+type ErrorSpec struct {
+	// Code identifies the error across services, such as "PRODUCT_NOT_FOUND"
+	Code string `json:"code"`
+
+	// Name is the Go name without the "Err" prefix. It is derived from Code if empty.
+	Name string `json:"name,omitempty"`
+
+	// Text is the sentinel text
+	Text string `json:"text"`
+
+	// Doc describes the error for the generated doc comments
+	Doc string `json:"doc,omitempty"`
+
+	// Parents are the codes of the parent errors
+	Parents []string `json:"parents,omitempty"`
+
+	// Display is the message safe to show to end users
+	Display string `json:"display,omitempty"`
+
+	// HTTPStatus is the HTTP status code to respond with
+	HTTPStatus int `json:"http_status,omitempty"`
+
+	// Retryable reports whether the failed operation may succeed when retried
+	Retryable bool `json:"retryable,omitempty"`
+
+	// DocsURL links to the documentation of the error
+	DocsURL string `json:"docs_url,omitempty"`
+
+	// Attrs are the typed attributes taken by the constructor helpers
+	Attrs []*AttrSpec `json:"attrs,omitempty"`
+}
+
+// AttrSpec describes an attribute parameter of the constructor helpers.
+type AttrSpec struct {
+	// Name is the attribute key, such as "product_id"
+	Name string `json:"name"`
+
+	// Type is the Go type of the parameter, such as "string" or "time.Duration"
+	Type string `json:"type"`
+}
+
+// parseSpec reads and validates a JSON spec.
+func parseSpec(data []byte) (*Spec, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	var spec Spec
+	if err := dec.Decode(&spec); err != nil {
+		return nil, fmt.Errorf("parsing spec: %w", err)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// validate checks the spec and derives missing names.
+func (s *Spec) validate() error {
+	if !token.IsIdentifier(s.Package) {
+		return fmt.Errorf("invalid package name %q", s.Package)
+	}
+
+	codes := make(map[string]*ErrorSpec, len(s.Errors))
+	names := make(map[string]string, len(s.Errors))
+	for i, e := range s.Errors {
+		if e.Code == "" {
+			return fmt.Errorf("error %d: missing code", i)
+		}
+		if codes[e.Code] != nil {
+			return fmt.Errorf("error %s: duplicate code", e.Code)
+		}
+		codes[e.Code] = e
+
+		if e.Text == "" {
+			return fmt.Errorf("error %s: missing text", e.Code)
+		}
+		if e.Name == "" {
+			e.Name = exportedName(e.Code)
+		}
+		if !token.IsIdentifier(e.Name) || !token.IsExported(e.Name) {
+			return fmt.Errorf("error %s: invalid name %q", e.Code, e.Name)
+		}
+		if other, ok := names[e.Name]; ok {
+			return fmt.Errorf("error %s: name %q already used by %s", e.Code, e.Name, other)
+		}
+		names[e.Name] = e.Code
+	}
+
+	reserved := s.reservedParams()
+	for _, e := range s.Errors {
+		if err := validateAttrs(e, reserved); err != nil {
+			return err
+		}
+		for _, parent := range e.Parents {
+			if codes[parent] == nil {
+				return fmt.Errorf("error %s: unknown parent %s", e.Code, parent)
+			}
+		}
+	}
+	return checkCycles(s.Errors, codes)
+}
+
+// reservedParams returns the identifiers the constructor helpers can't take as
+// parameter names: the cause parameter, the imported packages and the package-level
+// names generated for the spec, which the helpers refer to.
+func (s *Spec) reservedParams() map[string]bool {
+	reserved := map[string]bool{
+		"cause": true, "errx": true, "errors": true, "fmt": true,
+		"metadata": true, "specificity": true,
+	}
+	for _, imp := range s.Imports {
+		reserved[importName(imp)] = true
+	}
+	for _, e := range s.Errors {
+		reserved["display"+e.Name] = true
+	}
+	return reserved
+}
+
+// importName returns the default package name of an import path: its last element,
+// skipping a major version suffix such as "/v2".
+func importName(importPath string) string {
+	dir, name := path.Split(importPath)
+	if len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" && dir != "" {
+		name = path.Base(dir)
+	}
+	return name
+}
+
+// validateAttrs checks the attributes of an error spec. reserved holds the
+// identifiers that can't be used as parameter names (see Spec.reservedParams).
+func validateAttrs(e *ErrorSpec, reserved map[string]bool) error {
+	params := make(map[string]bool, len(e.Attrs))
+	for _, attr := range e.Attrs {
+		if attr.Name == "" {
+			return fmt.Errorf("error %s: attribute without name", e.Code)
+		}
+		if _, err := parser.ParseExpr(attr.Type); attr.Type == "" || err != nil {
+			return fmt.Errorf("error %s: attribute %s: invalid type %q", e.Code, attr.Name, attr.Type)
+		}
+		param := paramName(attr.Name)
+		if reserved[param] {
+			return fmt.Errorf("error %s: attribute %s: parameter name %q is reserved by the generated code", e.Code, attr.Name, param)
+		}
+		if !token.IsIdentifier(param) || params[param] {
+			return fmt.Errorf("error %s: attribute %s: invalid or duplicate parameter name %q", e.Code, attr.Name, param)
+		}
+		params[param] = true
+	}
+	return nil
+}
+
+// checkCycles reports an error if the parents of the errors form a cycle.
+func checkCycles(errs []*ErrorSpec, codes map[string]*ErrorSpec) error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(errs))
+
+	var visit func(e *ErrorSpec, path []string) error
+	visit = func(e *ErrorSpec, path []string) error {
+		switch state[e.Code] {
+		case visiting:
+			return fmt.Errorf("parent cycle: %s", strings.Join(append(path, e.Code), " -> "))
+		case done:
+			return nil
+		}
+		state[e.Code] = visiting
+		for _, parent := range e.Parents {
+			if err := visit(codes[parent], append(path, e.Code)); err != nil {
+				return err
+			}
+		}
+		state[e.Code] = done
+		return nil
+	}
+
+	for _, e := range errs {
+		if err := visit(e, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// initialisms are written in upper case in Go names.
+var initialisms = map[string]bool{
+	"API": true, "DB": true, "HTTP": true, "ID": true, "IP": true,
+	"JSON": true, "SQL": true, "URL": true, "UUID": true,
+}
+
+// words splits a code or attribute name such as "PRODUCT_NOT_FOUND",
+// "product-id" or "productId" into lower case words.
+func words(s string) []string {
+	var result []string
+	var current []rune
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = current[:0]
+		}
+	}
+	runes := []rune(s)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+		case unicode.IsUpper(r) && i > 0 && unicode.IsLower(runes[i-1]):
+			flush()
+			current = append(current, r)
+		default:
+			current = append(current, r)
+		}
+	}
+	flush()
+	return result
+}
+
+// exportedName converts a code such as "PRODUCT_NOT_FOUND" to "ProductNotFound".
+func exportedName(code string) string {
+	var b strings.Builder
+	for _, w := range words(code) {
+		b.WriteString(titleWord(w))
+	}
+	return b.String()
+}
+
+// paramName converts an attribute name such as "product_id" to "productID".
+// Go keywords get a "Value" suffix.
+func paramName(name string) string {
+	ws := words(name)
+	if len(ws) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString(ws[0])
+	for _, w := range ws[1:] {
+		b.WriteString(titleWord(w))
+	}
+	if token.IsKeyword(b.String()) {
+		b.WriteString("Value")
+	}
+	return b.String()
+}
+
+// titleWord capitalizes a lower case word, writing initialisms in upper case.
+func titleWord(w string) string {
+	if upper := strings.ToUpper(w); initialisms[upper] {
+		return upper
+	}
+	r := []rune(w)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
@@ -0,0 +1,423 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+const (
+	errxPath       = "github.com/go-extras/errx"
+	compatPath     = "github.com/go-extras/errx/compat"
+	stacktracePath = "github.com/go-extras/errx/stacktrace"
+)
+
+// Diagnostic is a problem found by a check.
+type Diagnostic struct {
+	Pos     token.Position
+	Check   string
+	Message string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s (%s)", d.Pos, d.Message, d.Check)
+}
+
+// check is a single analysis, run on every file of every package.
+type check struct {
+	name string
+	doc  string
+	run  func(p *pass, file *ast.File)
+}
+
+// checks lists all available checks.
+var checks = []check{
+	{"badkey", "Attrs arguments that would be recorded under the !BADKEY key", checkBadKey},
+	{"discarded", "Wrap and Classify calls whose result is discarded", checkDiscarded},
+	{"localsentinel", "sentinels created inside functions instead of at package level", checkLocalSentinel},
+	{"errorsnew", "errors.New values used as compat classifications", checkErrorsNew},
+	{"cycle", "sentinels whose parents refer back to them", checkCycle},
+}
+
+// pass holds the state shared by the checks of a package.
+type pass struct {
+	pkg         *srcload.Package
+	diagnostics []Diagnostic
+	check       string
+
+	// sentinels maps sentinel texts to the names of the sentinels declared with them,
+	// across all loaded packages
+	sentinels map[string][]string
+}
+
+// report records a diagnostic for the current check.
+func (p *pass) report(pos token.Pos, format string, args ...any) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Pos:     p.pkg.Fset.Position(pos),
+		Check:   p.check,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// callee returns the package path and name of the function called by call.
+func (p *pass) callee(file *ast.File, call *ast.CallExpr) (string, string) {
+	return p.pkg.Callee(file, call)
+}
+
+// isSentinelConstructor reports whether a call creates a sentinel.
+func isSentinelConstructor(path, name string) bool {
+	return (path == errxPath || path == compatPath) && name == "NewSentinel"
+}
+
+// isWrapper reports whether a function returns a wrapped or classified error.
+func isWrapper(path, name string) bool {
+	switch path {
+	case errxPath, compatPath, stacktracePath:
+		return name == "Wrap" || name == "Classify" || name == "ClassifyNew"
+	}
+	return false
+}
+
+// checkBadKey reports Attrs arguments that parseAttrs records under "!BADKEY":
+// keys without a value, and arguments that are neither a string key nor an Attr.
+func checkBadKey(p *pass, file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok || call.Ellipsis.IsValid() {
+			return true
+		}
+		if path, name := p.callee(file, call); path != errxPath || (name != "Attrs" && name != "WithAttrs") {
+			return true
+		}
+
+		for i := 0; i < len(call.Args); i++ {
+			arg := call.Args[i]
+			switch attrArgKind(p.pkg, arg) {
+			case argAttr:
+			case argKey:
+				if i == len(call.Args)-1 {
+					p.report(arg.Pos(), "key %s has no value; it will be recorded as a value under !BADKEY", types.ExprString(arg))
+				}
+				i++
+			case argBad:
+				p.report(arg.Pos(), "%s is not a string key or Attr; it will be recorded under !BADKEY", types.ExprString(arg))
+			case argUnknown:
+				return true // The remaining arguments cannot be paired reliably
+			}
+		}
+		return true
+	})
+}
+
+// Kinds of Attrs arguments.
+const (
+	argUnknown = iota
+	argKey
+	argAttr
+	argBad
+)
+
+// attrArgKind classifies an Attrs argument the way parseAttrs does.
+func attrArgKind(pkg *srcload.Package, arg ast.Expr) int {
+	if tv, ok := pkg.Info.Types[arg]; ok && tv.Type != nil {
+		t := tv.Type
+		if basic, ok := t.(*types.Basic); ok && (basic.Kind() == types.String || basic.Kind() == types.UntypedString) {
+			return argKey
+		}
+		if isErrxAttr(t) {
+			return argAttr
+		}
+		if slice, ok := t.(*types.Slice); ok && isErrxAttr(slice.Elem()) {
+			return argAttr
+		}
+		if types.IsInterface(t) {
+			return argUnknown
+		}
+		return argBad
+	}
+
+	// Without type information, only literals can be classified
+	switch a := ast.Unparen(arg).(type) {
+	case *ast.BasicLit:
+		if a.Kind == token.STRING {
+			return argKey
+		}
+		return argBad
+	case *ast.CompositeLit:
+		if sel, ok := a.Type.(*ast.SelectorExpr); ok && (sel.Sel.Name == "Attr" || sel.Sel.Name == "AttrList") {
+			return argAttr
+		}
+	}
+	return argUnknown
+}
+
+// isErrxAttr reports whether t is errx.Attr or errx.AttrList.
+func isErrxAttr(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != errxPath {
+		return false
+	}
+	return named.Obj().Name() == "Attr" || named.Obj().Name() == "AttrList"
+}
+
+// checkDiscarded reports Wrap and Classify calls used as statements, whose
+// result, the wrapped error, is lost.
+func checkDiscarded(p *pass, file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		stmt, ok := n.(*ast.ExprStmt)
+		if !ok {
+			return true
+		}
+		call, ok := ast.Unparen(stmt.X).(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if path, name := p.callee(file, call); isWrapper(path, name) {
+			p.report(call.Pos(), "result of %s.%s is discarded; the classified error is lost", pkgName(path), name)
+		}
+		return true
+	})
+}
+
+// checkLocalSentinel reports sentinels created inside functions: every call
+// creates a new identity, so errors.Is never matches them from elsewhere.
+// Assignments in init functions and sentinels returned by factory functions are allowed.
+func checkLocalSentinel(p *pass, file *ast.File) {
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Body == nil || (fn.Recv == nil && fn.Name.Name == "init") {
+			continue
+		}
+		returned := make(map[*ast.CallExpr]bool)
+		ast.Inspect(fn.Body, func(n ast.Node) bool {
+			if ret, ok := n.(*ast.ReturnStmt); ok {
+				for _, result := range ret.Results {
+					if call, ok := ast.Unparen(result).(*ast.CallExpr); ok {
+						returned[call] = true
+					}
+				}
+			}
+			call, ok := n.(*ast.CallExpr)
+			if !ok || returned[call] {
+				return true
+			}
+			if path, name := p.callee(file, call); isSentinelConstructor(path, name) {
+				p.report(call.Pos(), "sentinel created inside function %s; declare it at package level so errors.Is can match it", fn.Name.Name)
+			}
+			return true
+		})
+	}
+}
+
+// checkErrorsNew reports errors.New values used as compat classifications:
+// errors.New calls, which create an identity that nothing can match, and
+// package-level errors.New variables with the same text as an existing sentinel.
+func checkErrorsNew(p *pass, file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		path, name := p.callee(file, call)
+		if path != compatPath {
+			return true
+		}
+
+		var classifications []ast.Expr
+		switch name {
+		case "Wrap":
+			if len(call.Args) > 2 {
+				classifications = call.Args[2:]
+			}
+		case "Classify", "ClassifyNew":
+			if len(call.Args) > 1 {
+				classifications = call.Args[1:]
+			}
+		}
+
+		for _, arg := range classifications {
+			if text, ok := p.errorsNewText(file, arg); ok {
+				p.report(arg.Pos(), "errors.New used as classification can never be matched with errors.Is%s", p.suggestion(text))
+				continue
+			}
+			if obj := p.referencedVar(arg); obj != nil {
+				if text, ok := p.errorsNewVarText(obj); ok {
+					if suggestion := p.suggestion(text); suggestion != "" {
+						p.report(arg.Pos(), "%s is created with errors.New%s", types.ExprString(arg), suggestion)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// errorsNewText returns the text of an errors.New call with a constant argument.
+func (p *pass) errorsNewText(file *ast.File, expr ast.Expr) (string, bool) {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 {
+		return "", false
+	}
+	if path, name := p.callee(file, call); path != "errors" || name != "New" {
+		return "", false
+	}
+	return constantString(p.pkg, call.Args[0])
+}
+
+// referencedVar returns the package-level variable of this package referenced by expr.
+func (p *pass) referencedVar(expr ast.Expr) *types.Var {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	v, ok := p.pkg.Info.Uses[ident].(*types.Var)
+	if !ok || p.pkg.Types == nil || v.Parent() != p.pkg.Types.Scope() {
+		return nil
+	}
+	return v
+}
+
+// errorsNewVarText returns the text of a package-level variable of this package
+// initialized with errors.New.
+func (p *pass) errorsNewVarText(v *types.Var) (string, bool) {
+	for _, file := range p.pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				vs := spec.(*ast.ValueSpec)
+				for i, name := range vs.Names {
+					if p.pkg.Info.Defs[name] == v && i < len(vs.Values) {
+						return p.errorsNewText(file, vs.Values[i])
+					}
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// suggestion returns a hint naming the sentinels declared with text, or "".
+func (p *pass) suggestion(text string) string {
+	names := p.sentinels[text]
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("; use sentinel %s instead", strings.Join(names, " or "))
+}
+
+// checkCycle reports package-level sentinels whose parents lead back to them.
+func checkCycle(p *pass, file *ast.File) {
+	// The graph covers the whole package, so it is only checked once, with the first file
+	if file != p.pkg.Files[0] {
+		return
+	}
+
+	parents := make(map[string][]string)
+	positions := make(map[string]token.Pos)
+	for _, f := range p.pkg.Files {
+		forEachSentinel(p.pkg, f, func(name *ast.Ident, call *ast.CallExpr) {
+			positions[name.Name] = name.Pos()
+			for _, arg := range call.Args[1:] {
+				if ident, ok := ast.Unparen(arg).(*ast.Ident); ok {
+					parents[name.Name] = append(parents[name.Name], ident.Name)
+				}
+			}
+		})
+	}
+
+	names := make([]string, 0, len(positions))
+	for name := range positions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	reported := make(map[string]bool)
+	for _, name := range names {
+		if cycle := findCycle(parents, name); cycle != nil && !reported[name] {
+			for _, member := range cycle {
+				reported[member] = true
+			}
+			p.report(positions[name], "sentinel parent cycle: %s", strings.Join(append(cycle, name), " -> "))
+		}
+	}
+}
+
+// findCycle returns the path from start back to start through parents, or nil.
+func findCycle(parents map[string][]string, start string) []string {
+	visited := make(map[string]bool)
+	var path []string
+	var visit func(name string) bool
+	visit = func(name string) bool {
+		path = append(path, name)
+		for _, parent := range parents[name] {
+			if parent == start {
+				return true
+			}
+			if !visited[parent] {
+				visited[parent] = true
+				if visit(parent) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+	if visit(start) {
+		return path
+	}
+	return nil
+}
+
+// forEachSentinel calls fn for every package-level variable of file
+// initialized with a sentinel constructor.
+func forEachSentinel(pkg *srcload.Package, file *ast.File, fn func(name *ast.Ident, call *ast.CallExpr)) {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if i >= len(vs.Values) {
+					break
+				}
+				call, ok := ast.Unparen(vs.Values[i]).(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					continue
+				}
+				if path, fnName := pkg.Callee(file, call); isSentinelConstructor(path, fnName) {
+					fn(name, call)
+				}
+			}
+		}
+	}
+}
+
+// constantString returns the value of a constant string expression.
+func constantString(pkg *srcload.Package, expr ast.Expr) (string, bool) {
+	if tv, ok := pkg.Info.Types[expr]; ok && tv.Value != nil && tv.Value.Kind() == constant.String {
+		return constant.StringVal(tv.Value), true
+	}
+	if lit, ok := expr.(*ast.BasicLit); ok && lit.Kind == token.STRING {
+		s, err := strconv.Unquote(lit.Value)
+		return s, err == nil
+	}
+	return "", false
+}
+
+// pkgName returns the last element of an import path.
+func pkgName(path string) string {
+	return path[strings.LastIndex(path, "/")+1:]
+}
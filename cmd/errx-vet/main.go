@@ -0,0 +1,155 @@
+// Errx-vet reports likely misuse of the errx packages.
+//
+// It parses and type-checks packages from source using only the standard
+// library, and runs the following checks:
+//
+//	badkey         Attrs arguments that would be recorded under the !BADKEY key:
+//	               keys without a value, and arguments that are neither a string
+//	               key nor an Attr
+//	discarded      Wrap and Classify calls whose result is discarded
+//	localsentinel  sentinels created inside functions instead of at package level
+//	errorsnew      errors.New values used as compat classifications, which can
+//	               never be matched, or duplicate an existing sentinel
+//	cycle          sentinels whose parents refer back to them
+//
+// Usage:
+//
+//	errx-vet [flags] [packages]
+//
+// Packages are directories; a trailing "/..." includes all directories below.
+// The default is the current directory. Test files are not checked.
+//
+// The flags are:
+//
+//	-checks list
+//	    Comma-separated list of checks to run (default all).
+//
+// Errx-vet exits with status 1 if it reports any problem, and 2 on errors.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+// errProblems is returned by run when diagnostics were reported.
+var errProblems = errors.New("problems found")
+
+func main() {
+	err := run(os.Args[1:], os.Stdout, os.Stderr)
+	switch {
+	case err == nil:
+	case errors.Is(err, errProblems):
+		os.Exit(1)
+	case errors.Is(err, flag.ErrHelp):
+		os.Exit(2)
+	default:
+		fmt.Fprintln(os.Stderr, "errx-vet:", err)
+		os.Exit(2)
+	}
+}
+
+// run executes the command with the given arguments.
+func run(args []string, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("errx-vet", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	enabled := flags.String("checks", "", "comma-separated `list` of checks to run (default all)")
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: errx-vet [flags] [packages]")
+		flags.PrintDefaults()
+		fmt.Fprintln(stderr, "\nchecks:")
+		for _, c := range checks {
+			fmt.Fprintf(stderr, "  %-14s %s\n", c.name, c.doc)
+		}
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	selected, err := selectChecks(*enabled)
+	if err != nil {
+		return err
+	}
+	pkgs, err := srcload.Load(flags.Args()...)
+	if err != nil {
+		return err
+	}
+
+	diagnostics := vet(pkgs, selected)
+	for _, d := range diagnostics {
+		fmt.Fprintln(stdout, d)
+	}
+	if len(diagnostics) > 0 {
+		return errProblems
+	}
+	return nil
+}
+
+// selectChecks returns the checks named in a comma-separated list, or all checks
+// for an empty list.
+func selectChecks(list string) ([]check, error) {
+	if list == "" {
+		return checks, nil
+	}
+	var selected []check
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, c := range checks {
+			if c.name == name {
+				selected = append(selected, c)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown check %q", name)
+		}
+	}
+	return selected, nil
+}
+
+// vet runs the checks on the packages and returns the diagnostics sorted by position.
+func vet(pkgs []*srcload.Package, selected []check) []Diagnostic {
+	sentinels := make(map[string][]string)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			forEachSentinel(pkg, file, func(name *ast.Ident, call *ast.CallExpr) {
+				if text, ok := constantString(pkg, call.Args[0]); ok {
+					sentinels[text] = append(sentinels[text], pkg.Name+"."+name.Name)
+				}
+			})
+		}
+	}
+
+	var diagnostics []Diagnostic
+	for _, pkg := range pkgs {
+		p := &pass{pkg: pkg, sentinels: sentinels}
+		for _, c := range selected {
+			p.check = c.name
+			for _, file := range pkg.Files {
+				c.run(p, file)
+			}
+		}
+		diagnostics = append(diagnostics, p.diagnostics...)
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Pos, diagnostics[j].Pos
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics
+}
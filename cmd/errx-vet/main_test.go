@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+// wantPattern matches the expectations of a fixture line: // want `regexp` ...
+var wantPattern = regexp.MustCompile("// want (`[^`]+`(?: `[^`]+`)*)\\s*$")
+
+// expectations reads the want comments of the Go files in dir, keyed by file:line.
+func expectations(t *testing.T, dir string) map[string][]*regexp.Regexp {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := make(map[string][]*regexp.Regexp)
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scanner := bufio.NewScanner(f)
+		for line := 1; scanner.Scan(); line++ {
+			m := wantPattern.FindStringSubmatch(scanner.Text())
+			if m == nil {
+				continue
+			}
+			for _, quoted := range strings.Split(m[1], "` `") {
+				key := fmt.Sprintf("%s:%d", file, line)
+				result[key] = append(result[key], regexp.MustCompile(strings.Trim(quoted, "`")))
+			}
+		}
+		_ = f.Close()
+	}
+	return result
+}
+
+func TestChecks(t *testing.T) {
+	for _, dir := range []string{"testdata/misuse", "testdata/cycle"} {
+		t.Run(dir, func(t *testing.T) {
+			pkgs, err := srcload.Load(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := expectations(t, dir)
+
+			for _, d := range vet(pkgs, checks) {
+				key := fmt.Sprintf("%s:%d", d.Pos.Filename, d.Pos.Line)
+				matched := false
+				for i, re := range want[key] {
+					if re.MatchString(d.Message) {
+						want[key] = append(want[key][:i], want[key][i+1:]...)
+						matched = true
+						break
+					}
+				}
+				if !matched {
+					t.Errorf("unexpected diagnostic: %s", d)
+				}
+			}
+			for key, res := range want {
+				for _, re := range res {
+					t.Errorf("%s: no diagnostic matching %q", key, re)
+				}
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"./testdata/..."}, &stdout, &stderr)
+	if !errors.Is(err, errProblems) {
+		t.Fatalf("run() error = %v, want errProblems", err)
+	}
+	if !strings.Contains(stdout.String(), "testdata/misuse/misuse.go:37:2: result of errx.Wrap is discarded; the classified error is lost (discarded)\n") {
+		t.Errorf("unexpected output:\n%s", stdout.String())
+	}
+}
+
+func TestRun_SelectedChecks(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-checks", "discarded, localsentinel", "./testdata/misuse"}, &stdout, &stderr)
+	if !errors.Is(err, errProblems) {
+		t.Fatalf("run() error = %v, want errProblems", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 4 {
+		t.Errorf("expected 4 diagnostics, got:\n%s", stdout.String())
+	}
+	for _, line := range lines {
+		if !strings.HasSuffix(line, "(discarded)") && !strings.HasSuffix(line, "(localsentinel)") {
+			t.Errorf("unexpected diagnostic %q", line)
+		}
+	}
+}
+
+func TestRun_Clean(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "clean.go"), []byte("package clean\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var stdout, stderr bytes.Buffer
+	if err := run([]string{dir}, &stdout, &stderr); err != nil {
+		t.Errorf("run() error = %v, output:\n%s", err, stdout.String())
+	}
+}
+
+func TestRun_UnknownCheck(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	err := run([]string{"-checks", "nope", "./testdata/misuse"}, &stdout, &stderr)
+	if err == nil || !strings.Contains(err.Error(), `unknown check "nope"`) {
+		t.Errorf("run() error = %v", err)
+	}
+}
@@ -0,0 +1,15 @@
+// Package cycle is a fixture for errx-vet tests. It does not compile: Go reports
+// the initialization cycle as well, but less clearly.
+package cycle
+
+import "github.com/go-extras/errx"
+
+var (
+	ErrA = errx.NewSentinel("a", ErrC) // want `sentinel parent cycle: ErrA -> ErrC -> ErrB -> ErrA`
+	ErrB = errx.NewSentinel("b", ErrA)
+	ErrC = errx.NewSentinel("c", ErrB)
+
+	ErrSelf = errx.NewSentinel("self", ErrSelf) // want `sentinel parent cycle: ErrSelf -> ErrSelf`
+
+	ErrOK = errx.NewSentinel("ok", ErrA)
+)
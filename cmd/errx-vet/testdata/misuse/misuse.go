@@ -0,0 +1,66 @@
+// Package misuse is a fixture for errx-vet tests. Lines ending with a want
+// comment must be reported with a message matching its regular expression.
+package misuse
+
+import (
+	"errors"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+type key string
+
+var (
+	ErrNotFound = errx.NewSentinel("not found")
+	ErrTimeout  = compat.NewSentinel("timeout")
+
+	errLegacyNotFound = errors.New("not found")
+	errUnique         = errors.New("unique")
+)
+
+const userKey = "user_id"
+
+func attrs(id int, k key, anyValue any, list errx.AttrList, args []any) {
+	_ = errx.Attrs("user_id", id, userKey, id)
+	_ = errx.Attrs(errx.Attr{Key: "a", Value: 1}, list, []errx.Attr{})
+	_ = errx.Attrs("user_id")     // want `key "user_id" has no value`
+	_ = errx.Attrs(id, "a")       // want `id is not a string key or Attr` `key "a" has no value`
+	_ = errx.Attrs(k, id)         // want `k is not a string key or Attr` `id is not a string key or Attr`
+	_ = errx.WithAttrs("a", 1, 2) // want `2 is not a string key or Attr`
+	_ = errx.Attrs(anyValue, 1)
+	_ = errx.Attrs(args...)
+}
+
+func discarded(err error) error {
+	errx.Wrap("failed", err)                 // want `result of errx.Wrap is discarded`
+	stacktrace.Classify(err, ErrNotFound)    // want `result of stacktrace.Classify is discarded`
+	compat.ClassifyNew("failed", ErrTimeout) // want `result of compat.ClassifyNew is discarded`
+	_ = errx.Wrap("failed", err)
+	return errx.Classify(err, ErrNotFound)
+}
+
+func local() error {
+	errLocal := errx.NewSentinel("local") // want `sentinel created inside function local`
+	return errx.Classify(errors.New("x"), errLocal)
+}
+
+// newSentinel is a factory, its callers are checked instead
+func newSentinel(text string) errx.Classified {
+	return errx.NewSentinel(text)
+}
+
+var errFromInit errx.Classified
+
+func init() {
+	errFromInit = errx.NewSentinel("from init")
+}
+
+func compatClassifications(err error) error {
+	_ = compat.Wrap("failed", err, errors.New("timeout")) // want `errors.New used as classification can never be matched with errors.Is; use sentinel misuse.ErrTimeout instead`
+	_ = compat.Classify(err, errors.New("other"))         // want `errors.New used as classification can never be matched with errors.Is$`
+	_ = compat.Classify(err, errLegacyNotFound)           // want `errLegacyNotFound is created with errors.New; use sentinel misuse.ErrNotFound instead`
+	_ = compat.Classify(err, errUnique, ErrNotFound)
+	return compat.ClassifyNew("failed", ErrTimeout)
+}
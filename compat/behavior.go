@@ -0,0 +1,182 @@
+package compat
+
+import (
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/internal/errchain"
+)
+
+// Behavior interfaces exposed by errors from the standard library and third-party
+// libraries. They are detected structurally anywhere in an error chain.
+type (
+	timeouter   interface{ Timeout() bool }
+	temporary   interface{ Temporary() bool }
+	statusCoder interface{ StatusCode() int }
+	coder       interface{ Code() string }
+)
+
+// WhenTimeout returns a Rule that applies the classifications when any error in the
+// chain implements Timeout() bool and reports true, as net.Error, url.Error and
+// many client libraries do.
+//
+// Example:
+//
+//	compat.WhenTimeout(ErrTimeout)
+func WhenTimeout(classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		return findInChain(err, func(t timeouter) bool { return t.Timeout() })
+	}, classifications)
+}
+
+// WhenTemporary returns a Rule that applies the classifications when any error in
+// the chain implements Temporary() bool and reports true.
+//
+// Example:
+//
+//	compat.WhenTemporary(ErrRetryable)
+func WhenTemporary(classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		return findInChain(err, func(t temporary) bool { return t.Temporary() })
+	}, classifications)
+}
+
+// WhenStatusCode returns a Rule that applies the classifications when any error in
+// the chain implements StatusCode() int and pred returns true for its code.
+// A nil pred matches any status code.
+//
+// Example:
+//
+//	compat.WhenStatusCode(func(code int) bool { return code == http.StatusNotFound }, ErrNotFound)
+//	compat.WhenStatusCode(func(code int) bool { return code >= 500 }, ErrUpstream)
+func WhenStatusCode(pred func(code int) bool, classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		return findInChain(err, func(s statusCoder) bool { return pred == nil || pred(s.StatusCode()) })
+	}, classifications)
+}
+
+// WhenCode returns a Rule that applies the classifications when any error in the
+// chain implements Code() string and pred returns true for its code.
+// A nil pred matches any code.
+//
+// Example:
+//
+//	compat.WhenCode(func(code string) bool { return code == "NoSuchKey" }, ErrNotFound)
+func WhenCode(pred func(code string) bool, classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		return findInChain(err, func(c coder) bool { return pred == nil || pred(c.Code()) })
+	}, classifications)
+}
+
+// StatusCodeAttr returns a Rule that attaches the status code of the first error in
+// the chain implementing StatusCode() int as an attribute with the given key.
+//
+// Example:
+//
+//	compat.StatusCodeAttr("http_status")
+func StatusCodeAttr(key string) Rule {
+	return Rule{
+		classify: func(err error) []errx.Classified {
+			var code int
+			if !findInChain(err, func(s statusCoder) bool { code = s.StatusCode(); return true }) {
+				return nil
+			}
+			return []errx.Classified{errx.Attrs(key, code)}
+		},
+	}
+}
+
+// CodeAttr returns a Rule that attaches the code of the first error in the chain
+// implementing Code() string as an attribute with the given key.
+//
+// Example:
+//
+//	compat.CodeAttr("error_code")
+func CodeAttr(key string) Rule {
+	return Rule{
+		classify: func(err error) []errx.Classified {
+			var code string
+			if !findInChain(err, func(c coder) bool { code = c.Code(); return true }) {
+				return nil
+			}
+			return []errx.Classified{errx.Attrs(key, code)}
+		},
+	}
+}
+
+// findInChain reports whether any error in err's chain implements T and satisfies pred.
+func findInChain[T any](err error, pred func(T) bool) bool {
+	found := false
+	errchain.Walk(err, func(e error) bool {
+		if v, ok := e.(T); ok && pred(v) {
+			found = true
+		}
+		return found
+	})
+	return found
+}
+
+// behavioralSentinel wraps a classification and reports timeout and temporary
+// behavior through Timeout() and Temporary().
+type behavioralSentinel struct {
+	errx.Classified
+	timeout   bool
+	temporary bool
+}
+
+// Unwrap returns the wrapped classification, so that errors.Is matches it and its parents.
+func (s *behavioralSentinel) Unwrap() error {
+	return s.Classified
+}
+
+// Timeout reports whether the sentinel marks errors as timeouts.
+func (s *behavioralSentinel) Timeout() bool {
+	return s.timeout
+}
+
+// Temporary reports whether the sentinel marks errors as temporary failures.
+func (s *behavioralSentinel) Temporary() bool {
+	return s.temporary
+}
+
+// MarkTimeout returns a classification that behaves like cls and additionally
+// reports Timeout() == true. Errors classified with it (via errx.Classify,
+// compat.Classify or a Translator) implement Timeout() bool, so that net/http and
+// retry libraries recognize them as timeouts.
+//
+// The returned value is a distinct sentinel: errors.Is matches both it and cls.
+// Declare it once and use it in place of cls. Sentinels created with cls' result
+// as a parent do not inherit the behavior.
+//
+// Example:
+//
+//	var ErrTimeout = compat.MarkTimeout(errx.NewSentinel("timeout"))
+//
+//	err := errx.Classify(cause, ErrTimeout)
+//	var netErr interface{ Timeout() bool }
+//	errors.As(err, &netErr) // true, and netErr.Timeout() == true
+func MarkTimeout(cls errx.Classified) errx.Classified {
+	s := markBehavior(cls)
+	s.timeout = true
+	return s
+}
+
+// MarkTemporary returns a classification that behaves like cls and additionally
+// reports Temporary() == true. See MarkTimeout for details.
+//
+// Example:
+//
+//	var ErrUnavailable = compat.MarkTemporary(errx.NewSentinel("service unavailable"))
+func MarkTemporary(cls errx.Classified) errx.Classified {
+	s := markBehavior(cls)
+	s.temporary = true
+	return s
+}
+
+// markBehavior returns a new behavioralSentinel wrapping cls, copying the behavior
+// of cls if it is already one so that MarkTimeout and MarkTemporary can be combined.
+func markBehavior(cls errx.Classified) *behavioralSentinel {
+	s := &behavioralSentinel{Classified: cls}
+	if b, ok := cls.(*behavioralSentinel); ok {
+		s.timeout, s.temporary = b.timeout, b.temporary
+	}
+	return s
+}
@@ -0,0 +1,212 @@
+package compat_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// apiError mimics an HTTP client error exposing status and code
+type apiError struct {
+	status int
+	code   string
+}
+
+func (e *apiError) Error() string   { return fmt.Sprintf("api error %d: %s", e.status, e.code) }
+func (e *apiError) StatusCode() int { return e.status }
+func (e *apiError) Code() string    { return e.code }
+
+// temporaryError reports Temporary only
+type temporaryError struct{}
+
+func (temporaryError) Error() string   { return "try again" }
+func (temporaryError) Temporary() bool { return true }
+
+func TestWhenTimeout(t *testing.T) {
+	tr := compat.NewTranslator(compat.WhenTimeout(ErrTranslatedTimeout))
+
+	timeout := fmt.Errorf("dial: %w", &net.OpError{Op: "dial", Err: &timeoutError{timeout: true}})
+	if !errors.Is(tr.Classify(timeout), ErrTranslatedTimeout) {
+		t.Error("expected timeout to be classified")
+	}
+
+	notTimeout := &net.OpError{Op: "dial", Err: &timeoutError{timeout: false}}
+	if errors.Is(tr.Classify(notTimeout), ErrTranslatedTimeout) {
+		t.Error("expected non-timeout not to be classified")
+	}
+}
+
+func TestWhenTimeout_DeeperInChain(t *testing.T) {
+	tr := compat.NewTranslator(compat.WhenTimeout(ErrTranslatedTimeout))
+
+	// The outer error implements Timeout() but reports false; the inner one reports true
+	inner := &timeoutError{timeout: true}
+	outer := &url.Error{Op: "Get", URL: "http://example.com", Err: fmt.Errorf("round trip: %w", inner)}
+	if outer.Timeout() {
+		t.Fatal("test setup: expected url.Error to report false")
+	}
+	if !errors.Is(tr.Classify(outer), ErrTranslatedTimeout) {
+		t.Error("expected a timeout anywhere in the chain to be detected")
+	}
+}
+
+func TestWhenTemporary(t *testing.T) {
+	tr := compat.NewTranslator(compat.WhenTemporary(ErrTranslatedBusy))
+	if !errors.Is(tr.Classify(fmt.Errorf("call: %w", temporaryError{})), ErrTranslatedBusy) {
+		t.Error("expected temporary error to be classified")
+	}
+}
+
+func TestWhenStatusCodeAndCode(t *testing.T) {
+	tr := compat.NewTranslator(
+		compat.WhenStatusCode(func(code int) bool { return code == 404 }, ErrTranslatedNotFound),
+		compat.WhenCode(func(code string) bool { return code == "Throttled" }, ErrTranslatedBusy),
+		compat.StatusCodeAttr("http_status"),
+		compat.CodeAttr("error_code"),
+	)
+
+	err := tr.Classify(fmt.Errorf("get object: %w", &apiError{status: 404, code: "NoSuchKey"}))
+	if !errors.Is(err, ErrTranslatedNotFound) {
+		t.Error("expected status code rule to apply")
+	}
+	if errors.Is(err, ErrTranslatedBusy) {
+		t.Error("expected code rule not to apply")
+	}
+	attrs := errx.ExtractAttrs(err)
+	if len(attrs) != 2 || attrs[0] != (errx.Attr{Key: "http_status", Value: 404}) || attrs[1] != (errx.Attr{Key: "error_code", Value: "NoSuchKey"}) {
+		t.Errorf("unexpected attributes %v", attrs)
+	}
+
+	err = tr.Classify(&apiError{status: 429, code: "Throttled"})
+	if !errors.Is(err, ErrTranslatedBusy) || errors.Is(err, ErrTranslatedNotFound) {
+		t.Error("expected only the code rule to apply")
+	}
+
+	plain := errors.New("plain")
+	if tr.Classify(plain) != plain {
+		t.Error("expected error without behavior to be returned unchanged")
+	}
+}
+
+func TestWhenStatusCodeAndCode_NilPredicate(t *testing.T) {
+	tr := compat.NewTranslator(
+		compat.WhenStatusCode(nil, ErrTranslatedNotFound),
+		compat.WhenCode(nil, ErrTranslatedBusy),
+	)
+
+	err := tr.Classify(&apiError{status: 500, code: "InternalError"})
+	if !errors.Is(err, ErrTranslatedNotFound) || !errors.Is(err, ErrTranslatedBusy) {
+		t.Error("expected nil predicates to match any code")
+	}
+
+	plain := errors.New("plain")
+	if tr.Classify(plain) != plain {
+		t.Error("expected error without codes to be returned unchanged")
+	}
+}
+
+func TestMarkTimeout(t *testing.T) {
+	base := errx.NewSentinel("timeout")
+	ErrTimeout := compat.MarkTimeout(base)
+
+	err := errx.Classify(errors.New("query took too long"), ErrTimeout)
+
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, base) {
+		t.Error("expected errors.Is to match the marked sentinel and its base")
+	}
+	if err.Error() != "query took too long" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+
+	// Direct assertion, as done by url.Error
+	to, ok := err.(interface{ Timeout() bool })
+	if !ok || !to.Timeout() {
+		t.Error("expected classified error to implement Timeout() == true")
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() || netErr.Temporary() {
+		t.Error("expected error to be recognized as a net.Error timeout")
+	}
+
+	// net/http wraps transport errors in url.Error, which asserts on its direct cause
+	urlErr := &url.Error{Op: "Get", URL: "http://example.com", Err: err}
+	if !urlErr.Timeout() {
+		t.Error("expected url.Error to report a timeout")
+	}
+}
+
+func TestMarkTemporaryCombined(t *testing.T) {
+	ErrFlaky := compat.MarkTemporary(compat.MarkTimeout(errx.NewSentinel("flaky")))
+
+	err := compat.Classify(errors.New("reset"), ErrFlaky)
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() || !netErr.Temporary() {
+		t.Error("expected both behaviors to be reported")
+	}
+}
+
+func TestMarkTimeoutWrap(t *testing.T) {
+	ErrTimeout := compat.MarkTimeout(errx.NewSentinel("timeout"))
+	err := errx.Wrap("query users", errors.New("slow"), ErrTimeout)
+
+	if err.Error() != "query users: slow" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrTimeout) {
+		t.Error("expected errors.Is to match the marked sentinel")
+	}
+	urlErr := &url.Error{Op: "Get", URL: "http://example.com", Err: err}
+	if !urlErr.Timeout() {
+		t.Error("expected url.Error to report a timeout for a wrapped error")
+	}
+	if urlErr.Temporary() {
+		t.Error("expected url.Error not to report a temporary failure")
+	}
+
+	plain := errx.Wrap("query users", errors.New("slow"), errx.NewSentinel("not found"))
+	if _, ok := plain.(interface{ Timeout() bool }); ok {
+		t.Error("expected plain wrapped error not to implement Timeout()")
+	}
+}
+
+func TestPlainClassificationIsNotNetError(t *testing.T) {
+	err := errx.Classify(errors.New("missing"), errx.NewSentinel("not found"))
+
+	if _, ok := err.(interface{ Timeout() bool }); ok {
+		t.Error("expected plain classified error not to implement Timeout()")
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		t.Error("expected plain classified error not to be a net.Error")
+	}
+}
+
+func TestMarkedCarrierDelegatesToCause(t *testing.T) {
+	ErrUnavailable := compat.MarkTemporary(errx.NewSentinel("unavailable"))
+	err := errx.Classify(fmt.Errorf("wait: %w", context.DeadlineExceeded), ErrUnavailable)
+
+	to, ok := err.(interface{ Timeout() bool })
+	if !ok || !to.Timeout() {
+		t.Error("expected Timeout() to fall back to the cause chain")
+	}
+}
+
+func TestMarkedSentinelAttributesAndJSON(t *testing.T) {
+	ErrTimeout := compat.MarkTimeout(errx.NewSentinel("timeout"))
+	err := errx.Wrap("query", errors.New("slow"), ErrTimeout, errx.Attrs("table", "users"))
+
+	if attrs := errx.ExtractAttrs(err); len(attrs) != 1 {
+		t.Errorf("expected attributes to be preserved, got %v", attrs)
+	}
+	serialized := errxjson.ToSerializedError(err)
+	if serialized.Cause == nil || len(serialized.Sentinels) != 1 || serialized.Sentinels[0] != "timeout" {
+		t.Errorf("expected marked sentinel to be serialized, got %+v", serialized)
+	}
+}
@@ -30,6 +30,16 @@
 //   - compat.Wrap(text, cause, classifications...) accepts error classifications
 //   - compat.Classify(cause, classifications...) accepts error classifications
 //
+// NewSentinel similarly accepts any error as a sentinel parent, so that errx sentinels
+// can be declared as kinds of standard library errors such as fs.ErrNotExist.
+//
+// It also provides Translator, which maps well-known foreign errors (such as
+// fs.ErrNotExist or context.DeadlineExceeded) onto errx classifications using
+// declarative rules.
+//
+// Adapt lifts chains built with the legacy Cause() error convention into the
+// Unwrap chain, so that errors.Is, errors.As and errx functions can traverse them.
+//
 // These functions internally convert the provided error values to errx.Classified types
 // before calling the parent package functions. This conversion is done by wrapping each
 // error in an errx.Classified wrapper that preserves the error's identity for errors.Is
@@ -121,6 +131,40 @@ func toClassified(err error) errx.Classified {
 	return &errorWrapper{err: err}
 }
 
+// toClassifiedSlice converts error classifications to Classified, skipping nil values.
+func toClassifiedSlice(classifications []error) []errx.Classified {
+	classified := make([]errx.Classified, 0, len(classifications))
+	for _, cls := range classifications {
+		if c := toClassified(cls); c != nil {
+			classified = append(classified, c)
+		}
+	}
+	return classified
+}
+
+// NewSentinel creates a new classification sentinel with the given text, like
+// errx.NewSentinel, but accepts any error as a parent. This lets an errx taxonomy
+// declare that its sentinels are kinds of standard library or third-party errors,
+// so that callers checking for those errors keep working.
+//
+// The result is a regular errx sentinel: errors.Is matches the sentinel itself and
+// all of its parents (and their parents), and errors.As can find parent error types.
+// Parents that are already errx.Classified are used as-is. Nil parents are ignored.
+//
+// Example:
+//
+//	var (
+//	    ErrNotFound = compat.NewSentinel("not found", fs.ErrNotExist)
+//	    ErrCanceled = compat.NewSentinel("canceled", context.Canceled)
+//	)
+//
+//	err := errx.Classify(errors.New("no such user"), ErrNotFound)
+//	errors.Is(err, ErrNotFound)    // true
+//	errors.Is(err, fs.ErrNotExist) // true
+func NewSentinel(text string, parents ...error) errx.Classified {
+	return errx.NewSentinel(text, toClassifiedSlice(parents)...)
+}
+
 // Wrap wraps an error with additional context text and optional classifications.
 // This is a compatibility function that accepts standard Go error interface for
 // classifications instead of requiring errx.Classified types.
@@ -148,13 +192,7 @@ func Wrap(text string, cause error, classifications ...error) error {
 		return nil
 	}
 
-	// Convert error classifications to Classified
-	classified := make([]errx.Classified, 0, len(classifications))
-	for _, cls := range classifications {
-		if c := toClassified(cls); c != nil {
-			classified = append(classified, c)
-		}
-	}
+	classified := toClassifiedSlice(classifications)
 
 	return errx.Wrap(text, cause, classified...)
 }
@@ -185,13 +223,7 @@ func Classify(cause error, classifications ...error) error {
 		return nil
 	}
 
-	// Convert error classifications to Classified
-	classified := make([]errx.Classified, 0, len(classifications))
-	for _, cls := range classifications {
-		if c := toClassified(cls); c != nil {
-			classified = append(classified, c)
-		}
-	}
+	classified := toClassifiedSlice(classifications)
 
 	return errx.Classify(cause, classified...)
 }
@@ -222,13 +254,7 @@ func Classify(cause error, classifications ...error) error {
 //	fmt.Println(errors.Is(err, ErrNotFound))        // Output: true
 //	fmt.Println(errors.Is(err, ErrDatabase))        // Output: true
 func ClassifyNew(text string, classifications ...error) error {
-	// Convert error classifications to Classified
-	classified := make([]errx.Classified, 0, len(classifications))
-	for _, cls := range classifications {
-		if c := toClassified(cls); c != nil {
-			classified = append(classified, c)
-		}
-	}
+	classified := toClassifiedSlice(classifications)
 
 	return errx.ClassifyNew(text, classified...)
 }
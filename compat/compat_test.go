@@ -1,7 +1,9 @@
 package compat_test
 
 import (
+	"context"
 	"errors"
+	"io/fs"
 	"testing"
 
 	"github.com/go-extras/errx"
@@ -405,3 +407,54 @@ func TestClassifyNew_WithNilClassifications(t *testing.T) {
 		t.Error("expected error to be classified as ErrNotFound")
 	}
 }
+
+func TestNewSentinel_StandardParents(t *testing.T) {
+	ErrNotFoundStd := compat.NewSentinel("not found", fs.ErrNotExist)
+	ErrCanceled := compat.NewSentinel("canceled", context.Canceled)
+
+	if ErrNotFoundStd.Error() != "not found" {
+		t.Errorf("expected 'not found', got %q", ErrNotFoundStd.Error())
+	}
+
+	err := errx.Wrap("fetch user", errors.New("no such user"), ErrNotFoundStd)
+	if !errors.Is(err, ErrNotFoundStd) {
+		t.Error("expected errors.Is to match the sentinel")
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected errors.Is to match the standard library parent")
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Error("expected errors.Is not to match an unrelated error")
+	}
+	if err.Error() != "fetch user: no such user" {
+		t.Errorf("expected message without sentinel text, got %q", err.Error())
+	}
+
+	if !errors.Is(compat.Classify(errors.New("stopped"), ErrCanceled), context.Canceled) {
+		t.Error("expected errors.Is to match context.Canceled")
+	}
+}
+
+func TestNewSentinel_MixedParents(t *testing.T) {
+	ErrStorage := errx.NewSentinel("storage")
+	ErrMissing := compat.NewSentinel("missing", ErrStorage, fs.ErrNotExist, nil)
+	ErrMissingObject := errx.NewSentinel("missing object", ErrMissing)
+
+	err := errx.Classify(errors.New("no such key"), ErrMissingObject)
+	for _, target := range []error{ErrMissingObject, ErrMissing, ErrStorage, fs.ErrNotExist} {
+		if !errors.Is(err, target) {
+			t.Errorf("expected errors.Is to match %q", target)
+		}
+	}
+}
+
+func TestNewSentinel_ErrorsAs(t *testing.T) {
+	parent := &fs.PathError{Op: "open", Path: "/etc/app.yaml", Err: fs.ErrNotExist}
+	ErrConfigMissing := compat.NewSentinel("config missing", parent)
+
+	err := errx.Classify(errors.New("cannot start"), ErrConfigMissing)
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) || pathErr.Path != "/etc/app.yaml" {
+		t.Error("expected errors.As to find the parent error type")
+	}
+}
@@ -1,8 +1,11 @@
 package compat_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/fs"
+	"net"
 
 	"github.com/go-extras/errx"
 	"github.com/go-extras/errx/compat"
@@ -231,3 +234,71 @@ func ExampleClassify_nilCause() {
 	// Output:
 	// Error is nil: true
 }
+
+// ExampleTranslator demonstrates mapping well-known errors onto an errx taxonomy
+func ExampleTranslator() {
+	var (
+		ErrNotFound = errx.NewSentinel("not found")
+		ErrTimeout  = errx.NewSentinel("timeout")
+	)
+
+	translator := compat.NewTranslator(
+		compat.WhenIs(fs.ErrNotExist, ErrNotFound),
+		compat.WhenIs(context.DeadlineExceeded, ErrTimeout),
+		compat.WhenAs(func(e *net.OpError) bool { return e.Timeout() }, ErrTimeout),
+	)
+
+	err := translator.Classify(fmt.Errorf("open config.yaml: %w", fs.ErrNotExist))
+
+	fmt.Println(err.Error())
+	fmt.Println("Is not found:", errors.Is(err, ErrNotFound))
+	fmt.Println("Is timeout:", errors.Is(err, ErrTimeout))
+
+	// Output:
+	// open config.yaml: file does not exist
+	// Is not found: true
+	// Is timeout: false
+}
+
+// ExampleNewSentinel demonstrates declaring sentinels as kinds of standard library errors
+func ExampleNewSentinel() {
+	var ErrNotFound = compat.NewSentinel("not found", fs.ErrNotExist)
+
+	err := errx.Wrap("failed to load profile", errors.New("no such user"), ErrNotFound)
+
+	fmt.Println(err.Error())
+	fmt.Println("Is not found:", errors.Is(err, ErrNotFound))
+	fmt.Println("Is fs.ErrNotExist:", errors.Is(err, fs.ErrNotExist))
+
+	// Output:
+	// failed to load profile: no such user
+	// Is not found: true
+	// Is fs.ErrNotExist: true
+}
+
+// legacyWrapper stands in for an error from a library predating Go 1.13 wrapping
+type legacyWrapper struct {
+	msg   string
+	cause error
+}
+
+func (e *legacyWrapper) Error() string { return e.msg + ": " + e.cause.Error() }
+func (e *legacyWrapper) Cause() error  { return e.cause }
+
+// ExampleAdapt demonstrates lifting legacy Cause() chains into the Unwrap chain
+func ExampleAdapt() {
+	var ErrNotFound = errx.NewSentinel("not found")
+
+	legacy := &legacyWrapper{msg: "query failed", cause: errx.Classify(errors.New("no rows"), ErrNotFound)}
+
+	fmt.Println("Is not found (legacy):", errors.Is(legacy, ErrNotFound))
+
+	err := compat.Adapt(legacy)
+	fmt.Println(err.Error())
+	fmt.Println("Is not found (adapted):", errors.Is(err, ErrNotFound))
+
+	// Output:
+	// Is not found (legacy): false
+	// query failed: no rows
+	// Is not found (adapted): true
+}
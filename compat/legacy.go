@@ -0,0 +1,196 @@
+package compat
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx/internal/errptr"
+	"github.com/go-extras/errx/internal/foreignstack"
+)
+
+// causer is the legacy interface for error chains, used before Go 1.13
+// introduced Unwrap (for example by older versions of github.com/pkg/errors).
+type causer interface {
+	Cause() error
+}
+
+// causeAdapter lifts an error implementing only Cause() into the Unwrap chain.
+type causeAdapter struct {
+	err   error // The original error; implements Cause() but not Unwrap()
+	cause error // The adapted cause
+}
+
+func (a *causeAdapter) Error() string {
+	return a.err.Error()
+}
+
+// Unwrap returns the adapted cause, continuing the chain past the legacy error.
+func (a *causeAdapter) Unwrap() error {
+	return a.cause
+}
+
+// Cause returns the adapted cause, so that code using the legacy convention keeps working.
+func (a *causeAdapter) Cause() error {
+	return a.cause
+}
+
+// Is reports whether the original error matches target.
+// The original error has no Unwrap, so only it is checked; the rest of the chain
+// is reached by errors.Is through Unwrap.
+func (a *causeAdapter) Is(target error) bool {
+	return errors.Is(a.err, target)
+}
+
+// As finds the first error in the original error matching target, preserving its
+// concrete type for errors.As.
+func (a *causeAdapter) As(target any) bool {
+	return errors.As(a.err, target)
+}
+
+// callersMethod forwards Callers() []uintptr, as implemented by github.com/go-errors/errors.
+type callersMethod struct {
+	provider interface{ Callers() []uintptr }
+}
+
+func (m callersMethod) Callers() []uintptr {
+	return m.provider.Callers()
+}
+
+// stackTraceMethod forwards StackTrace() returning a slice of program counters, as
+// implemented by github.com/pkg/errors. The slice type of the original error can't
+// be named without importing its package, so the program counters are returned as
+// []uintptr, which stack trace consumers recognize structurally as well.
+type stackTraceMethod struct {
+	stackTrace func() []uintptr
+}
+
+func (m stackTraceMethod) StackTrace() []uintptr {
+	return m.stackTrace()
+}
+
+// formatMethod forwards fmt.Formatter, for errors rendering their stack with %+v.
+type formatMethod struct {
+	formatter fmt.Formatter
+}
+
+func (m formatMethod) Format(s fmt.State, verb rune) {
+	m.formatter.Format(s, verb)
+}
+
+// withForwardedMethods returns a, extended with the stack trace and formatting methods
+// implemented by the original error. Only the methods the original error implements
+// are added, so that structural detection doesn't stop at an adapter with nothing to
+// forward.
+func withForwardedMethods(a *causeAdapter) error {
+	c, hasCallers := a.err.(interface{ Callers() []uintptr })
+	stackTrace, hasStackTrace := foreignstack.StackTrace(a.err)
+	st := stackTraceMethod{stackTrace}
+	f, hasFormat := a.err.(fmt.Formatter)
+
+	switch {
+	case hasCallers && hasStackTrace && hasFormat:
+		return &struct {
+			*causeAdapter
+			callersMethod
+			stackTraceMethod
+			formatMethod
+		}{a, callersMethod{c}, st, formatMethod{f}}
+	case hasCallers && hasStackTrace:
+		return &struct {
+			*causeAdapter
+			callersMethod
+			stackTraceMethod
+		}{a, callersMethod{c}, st}
+	case hasCallers && hasFormat:
+		return &struct {
+			*causeAdapter
+			callersMethod
+			formatMethod
+		}{a, callersMethod{c}, formatMethod{f}}
+	case hasStackTrace && hasFormat:
+		return &struct {
+			*causeAdapter
+			stackTraceMethod
+			formatMethod
+		}{a, st, formatMethod{f}}
+	case hasCallers:
+		return &struct {
+			*causeAdapter
+			callersMethod
+		}{a, callersMethod{c}}
+	case hasStackTrace:
+		return &struct {
+			*causeAdapter
+			stackTraceMethod
+		}{a, st}
+	case hasFormat:
+		return &struct {
+			*causeAdapter
+			formatMethod
+		}{a, formatMethod{f}}
+	default:
+		return a
+	}
+}
+
+// Adapt lifts errors that implement the legacy Cause() error convention, but not
+// Unwrap, into wrappers that implement Unwrap. This lets errors.Is, errors.As,
+// errx.ExtractAttrs and the json package traverse chains built by libraries that
+// predate Go 1.13 error wrapping.
+//
+// Messages are preserved, and errors.As still finds the original concrete types.
+// The adapted errors also keep implementing Cause() for legacy callers, and forward
+// the stack trace methods Callers() and StackTrace() and fmt.Formatter when the
+// original errors implement them, so stacktrace.Extract and %+v still see their stacks.
+//
+// Adapt rebuilds the chain while errors implement only Cause(). An error that
+// already implements Unwrap is returned as-is, so Adapt should be applied where
+// a legacy error enters your code, before wrapping it further. If err is nil,
+// Adapt returns nil. Cyclic Cause() chains are adapted up to the first repeated
+// error, which is kept as-is and ends the Unwrap chain.
+//
+// Example:
+//
+//	resp, err := legacyClient.Do(req)
+//	if err != nil {
+//	    return errx.Wrap("legacy call failed", compat.Adapt(err), ErrUpstream)
+//	}
+func Adapt(err error) error {
+	return adapt(err, nil)
+}
+
+// maxAdaptDepth bounds the number of adapters created for a single chain, for cycles
+// that pointer identity can't detect, such as value errors returning copies of themselves.
+const maxAdaptDepth = 1000
+
+// adapt implements Adapt, recording the adapted errors in visited to stop at cycles.
+func adapt(err error, visited map[uintptr]bool) error {
+	if err == nil {
+		return nil
+	}
+
+	switch err.(type) {
+	case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+		return err
+	}
+
+	c, ok := err.(causer)
+	if !ok {
+		return err
+	}
+	cause := c.Cause()
+	if cause == nil || cause == err {
+		return err
+	}
+
+	if visited == nil {
+		visited = make(map[uintptr]bool)
+	}
+	ptr := errptr.Get(err)
+	if visited[ptr] || len(visited) >= maxAdaptDepth {
+		return err
+	}
+	visited[ptr] = true
+
+	return withForwardedMethods(&causeAdapter{err: err, cause: adapt(cause, visited)})
+}
@@ -0,0 +1,165 @@
+package compat_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// legacyError mimics pre-Go 1.13 wrappers implementing only Cause()
+type legacyError struct {
+	msg   string
+	cause error
+}
+
+func (e *legacyError) Error() string {
+	if e.cause == nil {
+		return e.msg
+	}
+	return e.msg + ": " + e.cause.Error()
+}
+
+func (e *legacyError) Cause() error { return e.cause }
+
+// legacyCodeError is a concrete legacy error type looked up with errors.As
+type legacyCodeError struct {
+	code int
+}
+
+func (e *legacyCodeError) Error() string { return "code error" }
+
+func TestAdapt_Nil(t *testing.T) {
+	if compat.Adapt(nil) != nil {
+		t.Error("expected nil for nil error")
+	}
+}
+
+func TestAdapt_ReachesPastLegacyErrors(t *testing.T) {
+	root := errx.Classify(errors.New("disk full"), ErrTranslatedNotFound, errx.Attrs("volume", "/data"))
+	legacy := &legacyError{msg: "outer", cause: &legacyError{msg: "inner", cause: root}}
+
+	if errors.Is(legacy, ErrTranslatedNotFound) {
+		t.Fatal("precondition: errors.Is should stop at legacy errors")
+	}
+
+	adapted := compat.Adapt(legacy)
+	if adapted.Error() != legacy.Error() {
+		t.Errorf("expected message %q, got %q", legacy.Error(), adapted.Error())
+	}
+	if !errors.Is(adapted, ErrTranslatedNotFound) {
+		t.Error("expected errors.Is to reach the classified root")
+	}
+	attrs := errx.ExtractAttrs(adapted)
+	if len(attrs) != 1 || attrs[0].Key != "volume" {
+		t.Errorf("expected volume attribute, got %v", attrs)
+	}
+}
+
+func TestAdapt_PreservesConcreteTypes(t *testing.T) {
+	code := &legacyCodeError{code: 42}
+	adapted := compat.Adapt(&legacyError{msg: "outer", cause: code})
+
+	var legacy *legacyError
+	if !errors.As(adapted, &legacy) || legacy.msg != "outer" {
+		t.Error("expected errors.As to find the outer legacy error")
+	}
+	var target *legacyCodeError
+	if !errors.As(adapted, &target) || target.code != 42 {
+		t.Error("expected errors.As to find the legacy root error")
+	}
+	if !errors.Is(adapted, code) {
+		t.Error("expected errors.Is to match the legacy root error")
+	}
+}
+
+func TestAdapt_KeepsCause(t *testing.T) {
+	root := errors.New("root")
+	adapted := compat.Adapt(&legacyError{msg: "outer", cause: root})
+
+	c, ok := adapted.(interface{ Cause() error })
+	if !ok {
+		t.Fatal("expected adapted error to implement Cause()")
+	}
+	if c.Cause() != root {
+		t.Error("expected Cause() to return the root")
+	}
+}
+
+func TestAdapt_LeavesOtherErrorsUnchanged(t *testing.T) {
+	plain := errors.New("plain")
+	if compat.Adapt(plain) != plain {
+		t.Error("expected error without Cause() to be returned as-is")
+	}
+
+	wrapped := errx.Wrap("context", &legacyError{msg: "legacy"})
+	if compat.Adapt(wrapped) != wrapped {
+		t.Error("expected error implementing Unwrap to be returned as-is")
+	}
+
+	noCause := &legacyError{msg: "legacy"}
+	if compat.Adapt(noCause) != error(noCause) {
+		t.Error("expected error with nil Cause() to be returned as-is")
+	}
+}
+
+// cyclicLegacyError implements only Cause(), which may point back up the chain
+type cyclicLegacyError struct {
+	msg   string
+	cause error
+}
+
+func (e *cyclicLegacyError) Error() string { return e.msg }
+func (e *cyclicLegacyError) Cause() error  { return e.cause }
+
+func TestAdapt_Cycle(t *testing.T) {
+	a := &cyclicLegacyError{msg: "a"}
+	b := &cyclicLegacyError{msg: "b", cause: a}
+	a.cause = b
+
+	adapted := compat.Adapt(a)
+	if adapted.Error() != "a" {
+		t.Errorf("expected message %q, got %q", "a", adapted.Error())
+	}
+	if !errors.Is(adapted, b) {
+		t.Error("expected errors.Is to reach the second error of the cycle")
+	}
+
+	// The chain ends at the first repeated error
+	var depth int
+	for e := adapted; e != nil; e = errors.Unwrap(e) {
+		depth++
+		if depth > 3 {
+			t.Fatal("expected the adapted chain to end at the cycle")
+		}
+	}
+	if depth != 3 {
+		t.Errorf("expected 3 errors in the adapted chain, got %d", depth)
+	}
+}
+
+func TestAdapt_JSON(t *testing.T) {
+	adapted := compat.Adapt(&legacyError{msg: "outer", cause: errors.New("root")})
+
+	serialized := errxjson.ToSerializedError(errx.Wrap("request failed", adapted))
+	if serialized.Cause == nil || serialized.Cause.Cause == nil {
+		t.Fatal("expected cause chain to continue past the legacy error")
+	}
+	if serialized.Cause.Cause.Message != "root" {
+		t.Errorf("expected root message, got %q", serialized.Cause.Cause.Message)
+	}
+}
+
+func TestAdapt_OnlyForwardsImplementedMethods(t *testing.T) {
+	adapted := compat.Adapt(&legacyError{msg: "outer", cause: fmt.Errorf("inner")})
+
+	if _, ok := adapted.(fmt.Formatter); ok {
+		t.Error("expected no Format for a legacy error without one")
+	}
+	if _, ok := adapted.(interface{ Callers() []uintptr }); ok {
+		t.Error("expected no Callers for a legacy error without one")
+	}
+}
@@ -0,0 +1,80 @@
+//go:build !errx_notrace
+
+package compat_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// legacyCallersError mimics a github.com/go-errors/errors error with a legacy Cause()
+type legacyCallersError struct {
+	legacyError
+	stack []uintptr
+}
+
+func (e *legacyCallersError) Callers() []uintptr { return e.stack }
+
+// legacyFrame and legacyStackTrace mimic github.com/pkg/errors.Frame and StackTrace
+type (
+	legacyFrame      uintptr
+	legacyStackTrace []legacyFrame
+)
+
+// legacyStackError mimics a github.com/pkg/errors error predating Unwrap
+type legacyStackError struct {
+	legacyError
+	stack legacyStackTrace
+}
+
+func (e *legacyStackError) StackTrace() legacyStackTrace { return e.stack }
+
+func (e *legacyStackError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprintf(s, "%s\nwith %d frames", e.Error(), len(e.stack))
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+func callers() []uintptr {
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(2, pcs)]
+}
+
+func TestAdapt_ForwardsCallers(t *testing.T) {
+	legacy := &legacyCallersError{legacyError: legacyError{msg: "outer", cause: fmt.Errorf("inner")}, stack: callers()}
+
+	want := len(stacktrace.Extract(errx.Wrap("x", legacy)))
+	if want == 0 {
+		t.Fatal("precondition: expected frames from the legacy error")
+	}
+	if got := len(stacktrace.Extract(errx.Wrap("x", compat.Adapt(legacy)))); got != want {
+		t.Errorf("expected %d frames through Adapt, got %d", want, got)
+	}
+}
+
+func TestAdapt_ForwardsStackTraceAndFormat(t *testing.T) {
+	pcs := callers()
+	stack := make(legacyStackTrace, len(pcs))
+	for i, pc := range pcs {
+		stack[i] = legacyFrame(pc)
+	}
+	legacy := &legacyStackError{legacyError: legacyError{msg: "outer", cause: fmt.Errorf("inner")}, stack: stack}
+	adapted := compat.Adapt(legacy)
+
+	if got := len(stacktrace.Extract(errx.Wrap("x", adapted))); got != len(pcs) {
+		t.Errorf("expected %d frames through Adapt, got %d", len(pcs), got)
+	}
+	if got, want := fmt.Sprintf("%+v", adapted), fmt.Sprintf("%+v", legacy); got != want {
+		t.Errorf("expected %%+v to be forwarded, got %q, want %q", got, want)
+	}
+	if st, ok := adapted.(interface{ StackTrace() []uintptr }); !ok || len(st.StackTrace()) != len(pcs) {
+		t.Error("expected StackTrace() to be forwarded")
+	}
+}
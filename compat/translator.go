@@ -0,0 +1,139 @@
+package compat
+
+import (
+	"errors"
+
+	"github.com/go-extras/errx"
+)
+
+// Rule maps errors matching a condition onto classifications.
+// Rules are created with WhenIs, WhenAs and When (and the auto-classification
+// rules such as WhenTimeout and StatusCodeAttr), and applied by a Translator.
+type Rule struct {
+	classify func(err error) []errx.Classified
+}
+
+// matchRule returns a Rule applying fixed classifications to errors matching pred.
+func matchRule(pred func(err error) bool, classifications []error) Rule {
+	classified := toClassifiedSlice(classifications)
+	return Rule{
+		classify: func(err error) []errx.Classified {
+			if pred == nil || !pred(err) {
+				return nil
+			}
+			return classified
+		},
+	}
+}
+
+// WhenIs returns a Rule that applies the classifications to errors for which
+// errors.Is(err, target) is true.
+//
+// Example:
+//
+//	compat.WhenIs(fs.ErrNotExist, ErrNotFound)
+//	compat.WhenIs(context.DeadlineExceeded, ErrTimeout)
+func WhenIs(target error, classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		return errors.Is(err, target)
+	}, classifications)
+}
+
+// WhenAs returns a Rule that applies the classifications to errors whose chain
+// contains an error of type T (as found by errors.As) for which pred returns true.
+// A nil pred matches any error of type T.
+//
+// Example:
+//
+//	compat.WhenAs(func(e *net.OpError) bool { return e.Timeout() }, ErrTimeout)
+//	compat.WhenAs[*json.SyntaxError](nil, ErrInvalidInput)
+func WhenAs[T error](pred func(T) bool, classifications ...error) Rule {
+	return matchRule(func(err error) bool {
+		var target T
+		if !errors.As(err, &target) {
+			return false
+		}
+		return pred == nil || pred(target)
+	}, classifications)
+}
+
+// When returns a Rule that applies the classifications to errors for which pred returns true.
+//
+// Example:
+//
+//	compat.When(func(err error) bool {
+//	    return strings.Contains(err.Error(), "too many connections")
+//	}, ErrRetryable)
+func When(pred func(err error) bool, classifications ...error) Rule {
+	return matchRule(pred, classifications)
+}
+
+// Translator maps foreign errors onto errx classifications using a set of rules.
+// It replaces the switch statements typically written at service boundaries to
+// translate library errors into an application's error taxonomy.
+//
+// A Translator is immutable once created and safe for concurrent use.
+type Translator struct {
+	rules []Rule
+}
+
+// NewTranslator creates a Translator applying the given rules in order.
+//
+// Example:
+//
+//	var translator = compat.NewTranslator(
+//	    compat.WhenIs(fs.ErrNotExist, ErrNotFound),
+//	    compat.WhenIs(context.DeadlineExceeded, ErrTimeout),
+//	    compat.WhenAs(func(e *net.OpError) bool { return e.Timeout() }, ErrTimeout),
+//	)
+func NewTranslator(rules ...Rule) *Translator {
+	return &Translator{rules: rules}
+}
+
+// Classify attaches the classifications of every matching rule to err, without
+// altering its message. Rules are evaluated in order and all matching rules apply.
+//
+// If err is nil, Classify returns nil. If no rule matches, err is returned unchanged.
+//
+// Example:
+//
+//	_, err := os.Open(path)
+//	err = translator.Classify(err)
+//	errors.Is(err, ErrNotFound) // true if the file does not exist
+func (t *Translator) Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	classified := t.match(err)
+	if len(classified) == 0 {
+		return err
+	}
+	return errx.Classify(err, classified...)
+}
+
+// Wrap adds context text to err and attaches the classifications of every matching rule.
+// Rules are matched against err itself, as in Classify.
+//
+// If err is nil, Wrap returns nil.
+//
+// Example:
+//
+//	return translator.Wrap("failed to read config", err)
+func (t *Translator) Wrap(text string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return errx.Wrap(text, err, t.match(err)...)
+}
+
+// match returns the classifications of all rules matching err.
+func (t *Translator) match(err error) []errx.Classified {
+	var classified []errx.Classified
+	for _, rule := range t.rules {
+		if rule.classify != nil {
+			classified = append(classified, rule.classify(err)...)
+		}
+	}
+	return classified
+}
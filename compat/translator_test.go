@@ -0,0 +1,149 @@
+package compat_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+)
+
+var (
+	ErrTranslatedNotFound = errx.NewSentinel("not found")
+	ErrTranslatedTimeout  = errx.NewSentinel("timeout")
+	ErrTranslatedNetwork  = errx.NewSentinel("network")
+	ErrTranslatedBusy     = errx.NewSentinel("busy")
+)
+
+// timeoutError is a net.Error with configurable Timeout
+type timeoutError struct {
+	timeout bool
+}
+
+func (*timeoutError) Error() string   { return "i/o timeout" }
+func (e *timeoutError) Timeout() bool { return e.timeout }
+func (*timeoutError) Temporary() bool { return false }
+
+func newTestTranslator() *compat.Translator {
+	return compat.NewTranslator(
+		compat.WhenIs(fs.ErrNotExist, ErrTranslatedNotFound),
+		compat.WhenIs(context.DeadlineExceeded, ErrTranslatedTimeout),
+		compat.WhenAs(func(e *net.OpError) bool { return e.Timeout() }, ErrTranslatedTimeout),
+		compat.WhenAs[*net.OpError](nil, ErrTranslatedNetwork),
+		compat.When(func(err error) bool {
+			return strings.Contains(err.Error(), "too many connections")
+		}, ErrTranslatedBusy),
+	)
+}
+
+func TestTranslator_Nil(t *testing.T) {
+	tr := newTestTranslator()
+	if err := tr.Classify(nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	if err := tr.Wrap("context", nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
+
+func TestTranslator_WhenIs(t *testing.T) {
+	tr := newTestTranslator()
+	_, openErr := os.Open("/definitely/does/not/exist")
+
+	err := tr.Classify(openErr)
+	if !errors.Is(err, ErrTranslatedNotFound) {
+		t.Error("expected error to be classified as not found")
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Error("expected original error to be preserved")
+	}
+	if err.Error() != openErr.Error() {
+		t.Errorf("expected message %q, got %q", openErr.Error(), err.Error())
+	}
+	var pathErr *fs.PathError
+	if !errors.As(err, &pathErr) {
+		t.Error("expected errors.As to find *fs.PathError")
+	}
+}
+
+func TestTranslator_ContextDeadline(t *testing.T) {
+	tr := newTestTranslator()
+	err := tr.Classify(fmt.Errorf("query: %w", context.DeadlineExceeded))
+	if !errors.Is(err, ErrTranslatedTimeout) {
+		t.Error("expected error to be classified as timeout")
+	}
+}
+
+func TestTranslator_WhenAs(t *testing.T) {
+	tr := newTestTranslator()
+
+	timeout := &net.OpError{Op: "dial", Net: "tcp", Err: &timeoutError{timeout: true}}
+	err := tr.Classify(timeout)
+	if !errors.Is(err, ErrTranslatedTimeout) {
+		t.Error("expected timeout OpError to be classified as timeout")
+	}
+	if !errors.Is(err, ErrTranslatedNetwork) {
+		t.Error("expected all matching rules to apply")
+	}
+
+	refused := &net.OpError{Op: "dial", Net: "tcp", Err: &timeoutError{timeout: false}}
+	err = tr.Classify(refused)
+	if errors.Is(err, ErrTranslatedTimeout) {
+		t.Error("expected non-timeout OpError not to be classified as timeout")
+	}
+	if !errors.Is(err, ErrTranslatedNetwork) {
+		t.Error("expected nil predicate to match any OpError")
+	}
+}
+
+func TestTranslator_When(t *testing.T) {
+	tr := newTestTranslator()
+	err := tr.Classify(errors.New("pq: too many connections"))
+	if !errors.Is(err, ErrTranslatedBusy) {
+		t.Error("expected predicate rule to apply")
+	}
+}
+
+func TestTranslator_NoMatch(t *testing.T) {
+	tr := newTestTranslator()
+	base := errors.New("unrelated")
+	if err := tr.Classify(base); err != base {
+		t.Error("expected unmatched error to be returned unchanged")
+	}
+}
+
+func TestTranslator_Wrap(t *testing.T) {
+	tr := newTestTranslator()
+	err := tr.Wrap("failed to read config", fmt.Errorf("open: %w", fs.ErrNotExist))
+
+	if err.Error() != "failed to read config: open: file does not exist" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrTranslatedNotFound) {
+		t.Error("expected error to be classified as not found")
+	}
+
+	err = tr.Wrap("context", errors.New("unrelated"))
+	if err.Error() != "context: unrelated" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+}
+
+func TestTranslator_StandardErrorClassifications(t *testing.T) {
+	tr := compat.NewTranslator(compat.WhenIs(fs.ErrNotExist, ErrNotFound, errx.Attrs("source", "fs")))
+	err := tr.Classify(fs.ErrNotExist)
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected standard error classification to apply")
+	}
+	attrs := errx.ExtractAttrs(err)
+	if len(attrs) != 1 || attrs[0].Key != "source" {
+		t.Errorf("expected source attribute, got %v", attrs)
+	}
+}
@@ -0,0 +1,108 @@
+package errx
+
+import (
+	"context"
+	"reflect"
+)
+
+// ctxAttrsKey is the context key for attributes added with ContextWithAttrs.
+type ctxAttrsKey struct{}
+
+// ContextWithAttrs returns a copy of ctx carrying the given attributes in addition
+// to those already carried by ctx. The attributes are attached to errors created
+// with WrapCtx and ClassifyCtx.
+//
+// Attributes accept the same input formats as Attrs. This lets request metadata
+// such as a request ID, tenant or user be attached once, typically in a middleware,
+// instead of in every handler:
+//
+//	ctx = errx.ContextWithAttrs(ctx, "request_id", requestID, "tenant", tenant)
+//	...
+//	return errx.WrapCtx(ctx, "failed to load order", err, ErrNotFound)
+func ContextWithAttrs(ctx context.Context, attrs ...any) context.Context {
+	parsed := parseAttrs(attrs)
+	if len(parsed) == 0 {
+		return ctx
+	}
+	existing, _ := ctx.Value(ctxAttrsKey{}).(AttrList)
+	merged := make(AttrList, 0, len(existing)+len(parsed))
+	merged = append(merged, existing...)
+	merged = append(merged, parsed...)
+	return context.WithValue(ctx, ctxAttrsKey{}, merged)
+}
+
+// AttrsFromContext returns the attributes carried by ctx, in the order they were added.
+// Returns nil if ctx carries no attributes.
+func AttrsFromContext(ctx context.Context) AttrList {
+	attrs, _ := ctx.Value(ctxAttrsKey{}).(AttrList)
+	if len(attrs) == 0 {
+		return nil
+	}
+	result := make(AttrList, len(attrs))
+	copy(result, attrs)
+	return result
+}
+
+// WrapCtx is like Wrap, but also attaches the attributes carried by ctx
+// (see ContextWithAttrs) as an attributed classification.
+// Attributes whose key and value are already attached to cause or to the given
+// classifications are not attached again.
+// If cause is nil, WrapCtx returns nil.
+func WrapCtx(ctx context.Context, text string, cause error, classifications ...Classified) error {
+	if cause == nil {
+		return nil
+	}
+	return Wrap(text, cause, withContextAttrs(ctx, cause, classifications)...)
+}
+
+// ClassifyCtx is like Classify, but also attaches the attributes carried by ctx
+// (see ContextWithAttrs) as an attributed classification.
+// Attributes whose key and value are already attached to cause or to the given
+// classifications are not attached again.
+// If cause is nil, ClassifyCtx returns nil.
+func ClassifyCtx(ctx context.Context, cause error, classifications ...Classified) error {
+	if cause == nil {
+		return nil
+	}
+	return Classify(cause, withContextAttrs(ctx, cause, classifications)...)
+}
+
+// withContextAttrs returns classifications with an attributed classification
+// holding the context attributes not yet attached to cause or classifications.
+// The classifications slice is not modified.
+func withContextAttrs(ctx context.Context, cause error, classifications []Classified) []Classified {
+	ctxAttrs, _ := ctx.Value(ctxAttrsKey{}).(AttrList)
+	if len(ctxAttrs) == 0 {
+		return classifications
+	}
+
+	existing := ExtractAttrs(cause)
+	for _, cls := range classifications {
+		existing = append(existing, ExtractAttrs(cls)...)
+	}
+
+	var missing []Attr
+	for _, attr := range ctxAttrs {
+		if !containsAttr(existing, attr) && !containsAttr(missing, attr) {
+			missing = append(missing, attr)
+		}
+	}
+	if len(missing) == 0 {
+		return classifications
+	}
+
+	result := make([]Classified, 0, len(classifications)+1)
+	result = append(result, classifications...)
+	return append(result, &attributed{attrs: missing})
+}
+
+// containsAttr reports whether attrs contains an attribute with the same key and value.
+// Values are compared with reflect.DeepEqual, so uncomparable values are safe.
+func containsAttr(attrs []Attr, attr Attr) bool {
+	for _, a := range attrs {
+		if a.Key == attr.Key && reflect.DeepEqual(a.Value, attr.Value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package errx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx"
+)
+
+func TestContextWithAttrs(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+	ctx = errx.ContextWithAttrs(ctx, errx.Attr{Key: "tenant", Value: "acme"})
+
+	attrs := errx.AttrsFromContext(ctx)
+	if attrs.String() != "request_id=req-1 tenant=acme" {
+		t.Errorf("unexpected attrs %v", attrs)
+	}
+
+	// The returned list is a copy
+	attrs[0].Value = "changed"
+	if errx.AttrsFromContext(ctx)[0].Value != "req-1" {
+		t.Error("expected context attributes to be unaffected by changes to the returned list")
+	}
+}
+
+func TestContextWithAttrs_Empty(t *testing.T) {
+	ctx := context.Background()
+	if errx.ContextWithAttrs(ctx) != ctx {
+		t.Error("expected the same context without attributes")
+	}
+	if errx.AttrsFromContext(ctx) != nil {
+		t.Error("expected nil attributes")
+	}
+}
+
+func TestContextWithAttrs_ParentUnaffected(t *testing.T) {
+	parent := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+	_ = errx.ContextWithAttrs(parent, "user", "alice")
+
+	if attrs := errx.AttrsFromContext(parent); len(attrs) != 1 {
+		t.Errorf("expected parent context to keep 1 attribute, got %v", attrs)
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1", "tenant", "acme")
+	ErrNotFound := errx.NewSentinel("not found")
+
+	err := errx.WrapCtx(ctx, "failed to load order", errors.New("no rows"), ErrNotFound)
+	if err.Error() != "failed to load order: no rows" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected classification to be attached")
+	}
+	if attrs := errx.ExtractAttrs(err); attrs.String() != "request_id=req-1 tenant=acme" {
+		t.Errorf("unexpected attrs %v", attrs)
+	}
+
+	if errx.WrapCtx(ctx, "failed", nil) != nil {
+		t.Error("expected nil for nil cause")
+	}
+}
+
+func TestWrapCtx_WithoutContextAttrs(t *testing.T) {
+	err := errx.WrapCtx(context.Background(), "failed", errors.New("cause"))
+	if err.Error() != "failed: cause" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if errx.HasAttrs(err) {
+		t.Error("expected no attributes")
+	}
+}
+
+func TestClassifyCtx(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+	ErrRetryable := errx.NewSentinel("retryable")
+
+	err := errx.ClassifyCtx(ctx, errors.New("timeout"), ErrRetryable)
+	if err.Error() != "timeout" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if !errors.Is(err, ErrRetryable) {
+		t.Error("expected classification to be attached")
+	}
+	if attrs := errx.ExtractAttrs(err); attrs.String() != "request_id=req-1" {
+		t.Errorf("unexpected attrs %v", attrs)
+	}
+
+	if errx.ClassifyCtx(ctx, nil) != nil {
+		t.Error("expected nil for nil cause")
+	}
+}
+
+func TestWrapCtx_Deduplicates(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1", "user", "alice")
+
+	// Errors wrapped at each layer with the same context only carry the attributes once
+	inner := errx.ClassifyCtx(ctx, errors.New("no rows"))
+	middle := errx.WrapCtx(ctx, "query failed", inner)
+	outer := errx.WrapCtx(ctx, "load order", middle)
+
+	if attrs := errx.ExtractAttrs(outer); attrs.String() != "request_id=req-1 user=alice" {
+		t.Errorf("expected attributes once, got %v", attrs)
+	}
+
+	// Values differing from those lower in the chain are attached
+	other := errx.ContextWithAttrs(ctx, "user", "bob")
+	if attrs := errx.ExtractAttrs(errx.WrapCtx(other, "retry", outer)); len(attrs) != 3 {
+		t.Errorf("expected the new user value to be attached, got %v", attrs)
+	}
+
+	// Attributes passed explicitly are not duplicated
+	explicit := errx.WrapCtx(ctx, "failed", errors.New("cause"), errx.Attrs("request_id", "req-1"))
+	if attrs := errx.ExtractAttrs(explicit); attrs.String() != "request_id=req-1 user=alice" {
+		t.Errorf("unexpected attrs %v", attrs)
+	}
+}
+
+func TestWrapCtx_UncomparableValues(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "tags", []string{"a", "b"})
+
+	inner := errx.ClassifyCtx(ctx, errors.New("failed"))
+	outer := errx.WrapCtx(ctx, "outer", inner)
+	if attrs := errx.ExtractAttrs(outer); len(attrs) != 1 {
+		t.Errorf("expected equal slice values to be deduplicated, got %v", attrs)
+	}
+}
+
+func TestWrapCtx_DoesNotModifyClassifications(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+	classifications := make([]errx.Classified, 1, 2)
+	classifications[0] = errx.NewSentinel("a")
+	_ = errx.WrapCtx(ctx, "failed", errors.New("x"), classifications...)
+
+	if classifications[:2][1] != nil {
+		t.Error("expected the caller's backing array to be untouched")
+	}
+}
@@ -12,6 +12,9 @@ var _ Classified = (*displayable)(nil)
 // appropriate to display directly to end users.
 type displayable struct {
 	*sentinel
+
+	// texts holds the messages for specific audiences, see NewDisplayableFor
+	texts map[Audience]string
 }
 
 // NewDisplayable creates a new displayable error with the given message.
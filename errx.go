@@ -29,6 +29,9 @@
 //   - stacktrace: capture and extract stack traces for errx errors
 //   - json: serialize errx errors and their metadata to JSON
 //   - compat: work with the standard error interface while still using errx classifications
+//   - tracectx: attach distributed tracing IDs to errors
+//   - otelconv: convert errors into OpenTelemetry exception attributes
+//   - report: enrich, filter and send errors to sinks
 //
 // # When to Use
 //
@@ -233,6 +236,9 @@ func NewSentinel(text string, parents ...Classified) Classified {
 //
 // If no classifications are provided, Wrap behaves like fmt.Errorf with %w,
 // avoiding unnecessary carrier allocation.
+//
+// As with Classify, if any classification implements Timeout() bool or Temporary() bool,
+// the returned error implements these methods as well.
 func Wrap(text string, cause error, classifications ...Classified) error {
 	if cause == nil {
 		return nil
@@ -240,13 +246,21 @@ func Wrap(text string, cause error, classifications ...Classified) error {
 	if len(classifications) == 0 {
 		return fmt.Errorf("%s: %w", text, cause)
 	}
-	return fmt.Errorf("%s: %w", text, classify(cause, classifications...))
+	classified := classify(cause, classifications...)
+	if bc, ok := classified.(*behavioralCarrier); ok {
+		return &behavioralWrapper{msg: text + ": " + cause.Error(), carrier: bc}
+	}
+	return fmt.Errorf("%s: %w", text, classified)
 }
 
 // Classify attaches one or more classification sentinels to an existing error.
 // The attached classification sentinels can be used later to identify the error using errors.Is.
 // If err is nil, Classify returns nil.
 //
+// If any classification implements Timeout() bool or Temporary() bool, the returned
+// error implements these methods as well, so that net/http and retry libraries
+// recognize the classification. See the compat package for such classifications.
+//
 // Example:
 //
 //	var ErrNotFound = errx.NewSentinel("resource not found")
@@ -288,7 +302,11 @@ func classify(cause error, classifications ...Classified) error {
 	if cause == nil {
 		return nil
 	}
-	return &carrier{classifications: classifications, cause: cause}
+	c := carrier{classifications: classifications, cause: cause}
+	if hasBehavior(classifications) {
+		return &behavioralCarrier{carrier: c}
+	}
+	return &c
 }
 
 type carrier struct {
@@ -296,6 +314,12 @@ type carrier struct {
 	cause           error
 }
 
+// classificationList returns the classifications attached by the carrier.
+// It is promoted to behavioralCarrier, so both carrier types can be handled together.
+func (c *carrier) classificationList() []Classified {
+	return c.classifications
+}
+
 func (c *carrier) Error() string {
 	// IMPORTANT: classification sentinel text is intentionally NOT shown here
 	return c.cause.Error()
@@ -333,6 +357,82 @@ func (c *carrier) As(target any) bool {
 	return false
 }
 
+// timeouter and temporary are the behavior interfaces recognized by net/http,
+// the net package and most retry libraries.
+type (
+	timeouter interface{ Timeout() bool }
+	temporary interface{ Temporary() bool }
+)
+
+// hasBehavior reports whether any classification implements timeouter or temporary.
+func hasBehavior(classifications []Classified) bool {
+	for _, cls := range classifications {
+		switch cls.(type) {
+		case timeouter, temporary:
+			return true
+		}
+	}
+	return false
+}
+
+// behavioralCarrier is a carrier with at least one classification implementing
+// Timeout() bool or Temporary() bool. It implements both methods itself, so that
+// code asserting these interfaces directly on the error (such as url.Error in
+// net/http) recognizes the classification. Plain carriers deliberately do not
+// implement them, so that they are not mistaken for net.Error values.
+type behavioralCarrier struct {
+	carrier
+}
+
+// Timeout reports whether any classification reports a timeout, falling back to
+// the first error in the cause chain implementing Timeout() bool.
+func (c *behavioralCarrier) Timeout() bool {
+	for _, cls := range c.classifications {
+		if t, ok := cls.(timeouter); ok && t.Timeout() {
+			return true
+		}
+	}
+	var t timeouter
+	return errors.As(c.cause, &t) && t.Timeout()
+}
+
+// Temporary reports whether any classification reports a temporary failure, falling
+// back to the first error in the cause chain implementing Temporary() bool.
+func (c *behavioralCarrier) Temporary() bool {
+	for _, cls := range c.classifications {
+		if t, ok := cls.(temporary); ok && t.Temporary() {
+			return true
+		}
+	}
+	var t temporary
+	return errors.As(c.cause, &t) && t.Temporary()
+}
+
+// behavioralWrapper adds context text to a behavioralCarrier, like the fmt.Errorf
+// wrapper Wrap uses otherwise, and forwards Timeout() and Temporary() to it.
+type behavioralWrapper struct {
+	msg     string
+	carrier *behavioralCarrier
+}
+
+func (w *behavioralWrapper) Error() string {
+	return w.msg
+}
+
+func (w *behavioralWrapper) Unwrap() error {
+	return w.carrier
+}
+
+// Timeout reports whether the wrapped classified error reports a timeout.
+func (w *behavioralWrapper) Timeout() bool {
+	return w.carrier.Timeout()
+}
+
+// Temporary reports whether the wrapped classified error reports a temporary failure.
+func (w *behavioralWrapper) Temporary() bool {
+	return w.carrier.Temporary()
+}
+
 // simpleError is a simple error type that just holds a text message.
 // It's used internally by ClassifyNew to create a basic error.
 type simpleError string
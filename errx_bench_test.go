@@ -3,9 +3,12 @@ package errx_test
 import (
 	"errors"
 	"fmt"
+	"runtime"
 	"testing"
 
 	"github.com/go-extras/errx"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/stacktrace"
 )
 
 // Benchmark sentinel creation
@@ -396,6 +399,68 @@ func BenchmarkCombined_APIErrorHandling(b *testing.B) {
 	}
 }
 
+// Benchmark stack trace resolution
+func BenchmarkStacktrace_Extract(b *testing.B) {
+	err := stacktrace.Wrap("query failed", errors.New("connection reset"))
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = stacktrace.Extract(err)
+	}
+}
+
+func BenchmarkStacktrace_Extract_FreshTrace(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		err := stacktrace.Wrap("query failed", errors.New("connection reset"))
+		_ = stacktrace.Extract(err)
+	}
+}
+
+// BenchmarkStacktrace_Extract_Uncached resolves the same trace the way it was done
+// before frame caching, for comparison with BenchmarkStacktrace_Extract.
+func BenchmarkStacktrace_Extract_Uncached(b *testing.B) {
+	pcs := make([]uintptr, 32)
+	pcs = pcs[:runtime.Callers(1, pcs)]
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		frames := runtime.CallersFrames(pcs)
+		var result []stacktrace.Frame
+		for {
+			frame, more := frames.Next()
+			result = append(result, stacktrace.Frame{File: frame.File, Line: frame.Line, Function: frame.Function})
+			if !more {
+				break
+			}
+		}
+		_ = result
+	}
+}
+
+func BenchmarkStacktrace_ErrorString(b *testing.B) {
+	trace := stacktrace.Here()
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = trace.Error()
+	}
+}
+
+func BenchmarkJSON_Marshal_WithTrace(b *testing.B) {
+	ErrNotFound := errx.NewSentinel("not found")
+	ErrDatabase := errx.NewSentinel("database")
+	var err error
+	err = stacktrace.ClassifyNew("record missing", ErrNotFound, errx.Attrs("id", 42))
+	err = stacktrace.Wrap("query failed", err, ErrDatabase)
+	err = fmt.Errorf("handler error: %w", err)
+	b.ResetTimer()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = errxjson.Marshal(err)
+	}
+}
+
 // Benchmark comparison with standard library
 func BenchmarkStdlib_ErrorsNew(b *testing.B) {
 	b.ReportAllocs()
@@ -0,0 +1,204 @@
+package errxtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// AssertIs asserts that errors.Is(err, target) holds for every target.
+func AssertIs(t testing.TB, err error, targets ...error) bool {
+	t.Helper()
+
+	var failures []string
+	for _, target := range targets {
+		if !errors.Is(err, target) {
+			failures = append(failures, fmt.Sprintf("errors.Is(err, %v) = false", target))
+		}
+	}
+	return report(t, err, failures)
+}
+
+// AssertNotIs asserts that errors.Is(err, target) does not hold for any target.
+func AssertNotIs(t testing.TB, err error, targets ...error) bool {
+	t.Helper()
+
+	var failures []string
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			failures = append(failures, fmt.Sprintf("errors.Is(err, %v) = true", target))
+		}
+	}
+	return report(t, err, failures)
+}
+
+// AssertDisplayText asserts that errx.DisplayText(err) equals want.
+func AssertDisplayText(t testing.TB, err error, want string) bool {
+	t.Helper()
+
+	var failures []string
+	if got := errx.DisplayText(err); got != want {
+		failures = append(failures, fmt.Sprintf("display text = %q, want %q", got, want))
+	}
+	return report(t, err, failures)
+}
+
+// AssertAttr asserts that the first attribute with the given key in
+// errx.ExtractAttrs(err) has a value deeply equal to want.
+func AssertAttr(t testing.TB, err error, key string, want any) bool {
+	t.Helper()
+
+	var failures []string
+	attrs := errx.ExtractAttrs(err)
+	idx := slices.IndexFunc(attrs, func(attr errx.Attr) bool { return attr.Key == key })
+	switch {
+	case idx < 0:
+		failures = append(failures, fmt.Sprintf("attribute %q not found, want %+v", key, want))
+	case !reflect.DeepEqual(attrs[idx].Value, want):
+		failures = append(failures, fmt.Sprintf("attribute %q = %+v (%T), want %+v (%T)",
+			key, attrs[idx].Value, attrs[idx].Value, want, want))
+	}
+	return report(t, err, failures)
+}
+
+// AssertHasTrace asserts that err carries a stack trace, as reported by stacktrace.Extract.
+func AssertHasTrace(t testing.TB, err error) bool {
+	t.Helper()
+
+	var failures []string
+	if len(stacktrace.Extract(err)) == 0 {
+		failures = append(failures, "stack trace not found")
+	}
+	return report(t, err, failures)
+}
+
+// Node describes the expected shape of one layer of an errx tree, for AssertChain.
+//
+// A layer is an error in the chain with its own message, as listed by Tree.
+// Sentinels, DisplayText and Attrs describe what is attached at that layer, not
+// further down the chain. Zero values are not checked; use an empty, non-nil
+// slice to assert that a layer has no sentinels, attributes or causes.
+type Node struct {
+	// Message is the layer's Error() text
+	Message string
+
+	// Sentinels are the sentinels attached at this layer, in any order. They are
+	// matched with errors.Is, or by text for errors rebuilt with FromSerialized
+	Sentinels []errx.Classified
+
+	// DisplayText is the display text attached at this layer
+	DisplayText string
+
+	// Attrs are the attributes attached at this layer, in any order
+	Attrs errx.AttrList
+
+	// Causes are the layer's causes: one for a wrapped error, several for a multi-error
+	Causes []Node
+}
+
+// AssertChain asserts that the errx tree of err has the expected shape.
+// All differences are reported at once.
+func AssertChain(t testing.TB, err error, want Node) bool {
+	t.Helper()
+
+	var failures []string
+	if tree := buildTree(err); tree == nil {
+		failures = append(failures, "error is nil")
+	} else {
+		failures = matchNode(tree, want, "err", failures)
+	}
+	return report(t, err, failures)
+}
+
+// matchNode compares a layer with its expected shape, appending any differences
+// to failures. path identifies the layer in failure messages.
+func matchNode(l *layer, want Node, path string, failures []string) []string {
+	if want.Message != "" && l.message != want.Message {
+		failures = append(failures, fmt.Sprintf("%s: message = %q, want %q", path, l.message, want.Message))
+	}
+
+	if want.Sentinels != nil {
+		wantTexts := make([]string, len(want.Sentinels))
+		for i, s := range want.Sentinels {
+			wantTexts[i] = s.Error()
+		}
+		switch {
+		case !sameElements(l.sentinels, wantTexts):
+			failures = append(failures, fmt.Sprintf("%s: sentinels = [%s], want [%s]",
+				path, strings.Join(l.sentinels, ", "), strings.Join(wantTexts, ", ")))
+		case l.err != nil:
+			// The texts match; distinct sentinels may share a text, so check identity too
+			for _, s := range want.Sentinels {
+				if !errors.Is(l.err, s) {
+					failures = append(failures, fmt.Sprintf("%s: sentinel %q is a different sentinel with the same text", path, s.Error()))
+				}
+			}
+		}
+	}
+
+	if want.DisplayText != "" && l.display != want.DisplayText {
+		failures = append(failures, fmt.Sprintf("%s: display text = %q, want %q", path, l.display, want.DisplayText))
+	}
+
+	if want.Attrs != nil {
+		got := make([]string, len(l.attrs))
+		for i, attr := range l.attrs {
+			got[i] = attrString(attr)
+		}
+		wantAttrs := make([]string, len(want.Attrs))
+		for i, attr := range want.Attrs {
+			wantAttrs[i] = attr.String()
+		}
+		if !sameElements(got, wantAttrs) {
+			failures = append(failures, fmt.Sprintf("%s: attrs = [%s], want [%s]",
+				path, strings.Join(got, " "), strings.Join(wantAttrs, " ")))
+		}
+	}
+
+	if want.Causes == nil {
+		return failures
+	}
+	if len(l.causes) != len(want.Causes) {
+		return append(failures, fmt.Sprintf("%s: %d causes, want %d", path, len(l.causes), len(want.Causes)))
+	}
+	for i, cause := range l.causes {
+		failures = matchNode(cause, want.Causes[i], fmt.Sprintf("%s.causes[%d]", path, i), failures)
+	}
+	return failures
+}
+
+// sameElements reports whether a and b hold the same strings, ignoring order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(a, b)
+}
+
+// report fails the test with the given failures followed by the errx tree of err.
+// It returns whether there were no failures.
+func report(t testing.TB, err error, failures []string) bool {
+	t.Helper()
+
+	if len(failures) == 0 {
+		return true
+	}
+	var b strings.Builder
+	for _, failure := range failures {
+		b.WriteString(failure)
+		b.WriteByte('\n')
+	}
+	b.WriteString("error tree:\n")
+	writeTree(&b, buildTree(err))
+	t.Error(strings.TrimSuffix(b.String(), "\n"))
+	return false
+}
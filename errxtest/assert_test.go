@@ -0,0 +1,241 @@
+package errxtest_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/errxtest"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+var (
+	ErrNotFound = errx.NewSentinel("not found")
+	ErrDatabase = errx.NewSentinel("database")
+)
+
+// recorder captures failures reported through testing.TB
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Error(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) output() string {
+	return strings.Join(r.failures, "\n")
+}
+
+// sampleError builds a three-layer error with classifications at each layer
+func sampleError() error {
+	root := errx.Classify(errors.New("no rows"), errx.NewDisplayable("User not found"), errx.Attrs("user_id", 42))
+	return errx.Wrap("fetch user", root, ErrNotFound)
+}
+
+func TestTree(t *testing.T) {
+	want := `"fetch user: no rows" sentinels=[not found]
+└── "no rows" display="User not found" attrs=[user_id=42]
+    └── "no rows"`
+	if got := errxtest.Tree(sampleError()); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTree_MultiError(t *testing.T) {
+	err := errors.Join(errx.Classify(errors.New("a"), ErrDatabase), errors.New("b"))
+	want := `"a\nb"
+├── "a" sentinels=[database]
+│   └── "a"
+└── "b"`
+	if got := errxtest.Tree(err); got != want {
+		t.Errorf("Tree() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTree_Nil(t *testing.T) {
+	if got := errxtest.Tree(nil); got != "<nil>" {
+		t.Errorf("Tree(nil) = %q", got)
+	}
+}
+
+func TestAssertIs(t *testing.T) {
+	r := &recorder{TB: t}
+	if !errxtest.AssertIs(r, sampleError(), ErrNotFound) {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+
+	r = &recorder{TB: t}
+	if errxtest.AssertIs(r, sampleError(), ErrNotFound, ErrDatabase) {
+		t.Fatal("expected failure")
+	}
+	out := r.output()
+	if !strings.Contains(out, "errors.Is(err, database) = false") {
+		t.Errorf("expected failed target in output, got:\n%s", out)
+	}
+	if strings.Contains(out, "errors.Is(err, not found)") {
+		t.Errorf("expected only failed targets in output, got:\n%s", out)
+	}
+	if !strings.Contains(out, "error tree:\n\"fetch user: no rows\" sentinels=[not found]") {
+		t.Errorf("expected error tree in output, got:\n%s", out)
+	}
+}
+
+func TestAssertNotIs(t *testing.T) {
+	r := &recorder{TB: t}
+	if !errxtest.AssertNotIs(r, sampleError(), ErrDatabase) {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+
+	r = &recorder{TB: t}
+	if errxtest.AssertNotIs(r, sampleError(), ErrNotFound) {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(r.output(), "errors.Is(err, not found) = true") {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+}
+
+func TestAssertDisplayText(t *testing.T) {
+	r := &recorder{TB: t}
+	if !errxtest.AssertDisplayText(r, sampleError(), "User not found") {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+
+	r = &recorder{TB: t}
+	if errxtest.AssertDisplayText(r, sampleError(), "Not found") {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(r.output(), `display text = "User not found", want "Not found"`) {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+}
+
+func TestAssertAttr(t *testing.T) {
+	r := &recorder{TB: t}
+	if !errxtest.AssertAttr(r, sampleError(), "user_id", 42) {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+
+	r = &recorder{TB: t}
+	if errxtest.AssertAttr(r, sampleError(), "user_id", "42") {
+		t.Fatal("expected failure for mismatched type")
+	}
+	if !strings.Contains(r.output(), `attribute "user_id" = 42 (int), want 42 (string)`) {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+
+	r = &recorder{TB: t}
+	if errxtest.AssertAttr(r, sampleError(), "action", "delete") {
+		t.Fatal("expected failure for missing attribute")
+	}
+	if !strings.Contains(r.output(), `attribute "action" not found`) {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+}
+
+func TestAssertHasTrace(t *testing.T) {
+	r := &recorder{TB: t}
+	if errxtest.AssertHasTrace(r, sampleError()) {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(r.output(), "stack trace not found") {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+
+	if !stacktrace.Enabled {
+		return
+	}
+	r = &recorder{TB: t}
+	if !errxtest.AssertHasTrace(r, stacktrace.Wrap("traced", sampleError())) {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+}
+
+func TestAssertChain(t *testing.T) {
+	r := &recorder{TB: t}
+	ok := errxtest.AssertChain(r, sampleError(), errxtest.Node{
+		Message:   "fetch user: no rows",
+		Sentinels: []errx.Classified{ErrNotFound},
+		Attrs:     errx.AttrList{},
+		Causes: []errxtest.Node{{
+			Message:     "no rows",
+			DisplayText: "User not found",
+			Attrs:       errx.AttrList{{Key: "user_id", Value: 42}},
+			Causes:      []errxtest.Node{{Message: "no rows", Causes: []errxtest.Node{}}},
+		}},
+	})
+	if !ok {
+		t.Errorf("expected pass, got:\n%s", r.output())
+	}
+}
+
+func TestAssertChain_ReportsAllDifferences(t *testing.T) {
+	r := &recorder{TB: t}
+	ok := errxtest.AssertChain(r, sampleError(), errxtest.Node{
+		Message:   "fetch user: no rows",
+		Sentinels: []errx.Classified{ErrDatabase},
+		Causes: []errxtest.Node{{
+			DisplayText: "Missing",
+			Attrs:       errx.AttrList{{Key: "user_id", Value: 7}},
+			Causes:      []errxtest.Node{},
+		}},
+	})
+	if ok {
+		t.Fatal("expected failure")
+	}
+
+	out := r.output()
+	for _, want := range []string{
+		"err: sentinels = [not found], want [database]",
+		`err.causes[0]: display text = "User not found", want "Missing"`,
+		"err.causes[0]: attrs = [user_id=42], want [user_id=7]",
+		"err.causes[0]: 1 causes, want 0",
+		"error tree:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestAssertChain_SentinelIdentity(t *testing.T) {
+	// A sentinel of another package sharing the text of ErrNotFound
+	otherNotFound := errx.NewSentinel("not found")
+	err := sampleError()
+
+	r := &recorder{TB: t}
+	if errxtest.AssertChain(r, err, errxtest.Node{Sentinels: []errx.Classified{otherNotFound}}) {
+		t.Fatal("expected failure for a different sentinel with the same text")
+	}
+	if !strings.Contains(r.output(), `err: sentinel "not found" is a different sentinel with the same text`) {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+
+	// Serialized input only carries texts
+	r = &recorder{TB: t}
+	rebuilt := errxtest.FromSerialized(errxjson.ToSerializedError(err))
+	if !errxtest.AssertChain(r, rebuilt, errxtest.Node{Sentinels: []errx.Classified{otherNotFound}}) {
+		t.Errorf("expected serialized input to match by text, got:\n%s", r.output())
+	}
+}
+
+func TestAssertChain_Nil(t *testing.T) {
+	r := &recorder{TB: t}
+	if errxtest.AssertChain(r, nil, errxtest.Node{}) {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(r.output(), "error is nil\nerror tree:\n<nil>") {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+}
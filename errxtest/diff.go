@@ -0,0 +1,218 @@
+package errxtest
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// DiffOption configures Diff.
+type DiffOption func(*diffConfig)
+
+// diffConfig holds Diff configuration.
+type diffConfig struct {
+	traces bool
+}
+
+// WithTraces makes Diff also compare the top stack frame attached at each layer.
+// Traces are not compared by default, as they change with unrelated code changes.
+func WithTraces() DiffOption {
+	return func(c *diffConfig) {
+		c.traces = true
+	}
+}
+
+// Diff compares two errors structurally and returns a readable tree diff, or an
+// empty string if they are equivalent.
+//
+// The errx trees of both errors (see Tree) are compared layer by layer: the
+// message of each layer, and the sentinels, display text and attributes attached
+// at it. Sentinels and attributes are compared as sets, and attribute values by
+// their text, so that errors rebuilt from JSON (see FromSerialized) compare equal
+// to the errors they were serialized from.
+//
+// Unchanged layers are printed for context. Lines starting with "-" describe a,
+// lines starting with "+" describe b:
+//
+//	  "fetch user: no rows"
+//	-   sentinels=[not found]
+//	+   sentinels=[database]
+//	  └── "no rows"
+func Diff(a, b error, opts ...DiffOption) string {
+	cfg := &diffConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	d := &differ{cfg: cfg}
+	ta, tb := buildTree(a), buildTree(b)
+	switch {
+	case ta == nil && tb == nil:
+		return ""
+	case ta == nil:
+		d.line("-", "<nil>")
+		d.subtree("+", tb, "", "")
+	case tb == nil:
+		d.subtree("-", ta, "", "")
+		d.line("+", "<nil>")
+	default:
+		d.layers(ta, tb, "", "")
+	}
+
+	if !d.changed {
+		return ""
+	}
+	return strings.TrimSuffix(d.b.String(), "\n")
+}
+
+// differ accumulates the output of Diff.
+type differ struct {
+	cfg     *diffConfig
+	b       strings.Builder
+	changed bool
+}
+
+// line writes a single line with the given marker ("-", "+" or " ").
+func (d *differ) line(marker, text string) {
+	if marker != " " {
+		d.changed = true
+	}
+	d.b.WriteString(marker)
+	d.b.WriteByte(' ')
+	d.b.WriteString(text)
+	d.b.WriteByte('\n')
+}
+
+// layers compares two layers present in both trees, and then their causes.
+// prefix is written before the layers' own line, and indent before the lines of
+// their details and causes.
+func (d *differ) layers(a, b *layer, prefix, indent string) {
+	if a.message == b.message {
+		d.line(" ", prefix+fmt.Sprintf("%q", a.message))
+	} else {
+		d.line("-", prefix+fmt.Sprintf("%q", a.message))
+		d.line("+", prefix+fmt.Sprintf("%q", b.message))
+	}
+
+	detailsA, detailsB := d.details(a), d.details(b)
+	for i := range detailsA {
+		if detailsA[i] == detailsB[i] {
+			continue
+		}
+		if detailsA[i] != "" {
+			d.line("-", indent+"  "+detailsA[i])
+		}
+		if detailsB[i] != "" {
+			d.line("+", indent+"  "+detailsB[i])
+		}
+	}
+
+	n := max(len(a.causes), len(b.causes))
+	for i := range n {
+		childPrefix, childIndent := indent+"├── ", indent+"│   "
+		if i == n-1 {
+			childPrefix, childIndent = indent+"└── ", indent+"    "
+		}
+		switch {
+		case i >= len(b.causes):
+			d.subtree("-", a.causes[i], childPrefix, childIndent)
+		case i >= len(a.causes):
+			d.subtree("+", b.causes[i], childPrefix, childIndent)
+		default:
+			d.layers(a.causes[i], b.causes[i], childPrefix, childIndent)
+		}
+	}
+}
+
+// subtree writes a layer present in only one of the trees, with all its causes.
+func (d *differ) subtree(marker string, l *layer, prefix, indent string) {
+	d.line(marker, prefix+fmt.Sprintf("%q", l.message))
+	for _, detail := range d.details(l) {
+		if detail != "" {
+			d.line(marker, indent+"  "+detail)
+		}
+	}
+	for i, cause := range l.causes {
+		if i == len(l.causes)-1 {
+			d.subtree(marker, cause, indent+"└── ", indent+"    ")
+		} else {
+			d.subtree(marker, cause, indent+"├── ", indent+"│   ")
+		}
+	}
+}
+
+// details returns the comparable details of a layer in a fixed order, with an
+// empty string for absent details.
+func (d *differ) details(l *layer) []string {
+	details := make([]string, 4)
+	if len(l.sentinels) > 0 {
+		sentinels := slices.Clone(l.sentinels)
+		slices.Sort(sentinels)
+		details[0] = "sentinels=[" + strings.Join(sentinels, ", ") + "]"
+	}
+	if l.display != "" {
+		details[1] = fmt.Sprintf("display=%q", l.display)
+	}
+	if len(l.attrs) > 0 {
+		attrs := make([]string, len(l.attrs))
+		for i, attr := range l.attrs {
+			attrs[i] = attrString(attr)
+		}
+		slices.Sort(attrs)
+		details[2] = "attrs=[" + strings.Join(attrs, " ") + "]"
+	}
+	if d.cfg.traces && len(l.trace) > 0 {
+		details[3] = "trace=" + frameString(l.trace[0])
+	}
+	return details
+}
+
+// serializedError is an error rebuilt from a serialized error.
+type serializedError struct {
+	s *errxjson.SerializedError
+}
+
+func (e *serializedError) Error() string {
+	return e.s.Message
+}
+
+// Unwrap returns the rebuilt causes.
+func (e *serializedError) Unwrap() []error {
+	causes := e.s.Causes
+	if e.s.Cause != nil {
+		causes = []*errxjson.SerializedError{e.s.Cause}
+	}
+	result := make([]error, 0, len(causes))
+	for _, cause := range causes {
+		if cause != nil {
+			result = append(result, &serializedError{s: cause})
+		}
+	}
+	return result
+}
+
+// FromSerialized rebuilds an error from a serialized error, such as one read back
+// from JSON logs, so that it can be compared with Diff, rendered with Tree or
+// checked with AssertChain. It returns nil if s is nil.
+//
+// The rebuilt error only carries what was serialized: its message, sentinel texts,
+// display text, attributes and stack trace, as seen by the errxtest helpers.
+// errors.Is and the errx functions don't recognize its classifications.
+//
+// Example:
+//
+//	var logged errxjson.SerializedError
+//	if err := json.Unmarshal(line, &logged); err != nil {
+//	    t.Fatal(err)
+//	}
+//	if diff := errxtest.Diff(errxtest.FromSerialized(&logged), err); diff != "" {
+//	    t.Errorf("error changed:\n%s", diff)
+//	}
+func FromSerialized(s *errxjson.SerializedError) error {
+	if s == nil {
+		return nil
+	}
+	return &serializedError{s: s}
+}
@@ -0,0 +1,145 @@
+package errxtest_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/errxtest"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestDiff_Equal(t *testing.T) {
+	if diff := errxtest.Diff(sampleError(), sampleError()); diff != "" {
+		t.Errorf("expected no diff, got:\n%s", diff)
+	}
+	if diff := errxtest.Diff(nil, nil); diff != "" {
+		t.Errorf("expected no diff for nil errors, got:\n%s", diff)
+	}
+}
+
+func TestDiff_Classification(t *testing.T) {
+	changed := errx.Wrap("fetch user",
+		errx.Classify(errors.New("no rows"), errx.NewDisplayable("Missing"), errx.Attrs("user_id", 7)),
+		ErrDatabase)
+
+	want := `  "fetch user: no rows"
+-   sentinels=[not found]
++   sentinels=[database]
+  └── "no rows"
+-       display="User not found"
++       display="Missing"
+-       attrs=[user_id=42]
++       attrs=[user_id=7]
+      └── "no rows"`
+	if diff := errxtest.Diff(sampleError(), changed); diff != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, want)
+	}
+}
+
+func TestDiff_SameMessageDifferentClassification(t *testing.T) {
+	a := errx.Classify(errors.New("failed"), ErrNotFound)
+	b := errx.Classify(errors.New("failed"), ErrNotFound, ErrDatabase)
+	if a.Error() != b.Error() {
+		t.Fatal("precondition: messages should be equal")
+	}
+	if !strings.Contains(errxtest.Diff(a, b), "+   sentinels=[database, not found]") {
+		t.Errorf("expected sentinel diff, got:\n%s", errxtest.Diff(a, b))
+	}
+}
+
+func TestDiff_Messages(t *testing.T) {
+	want := `- "fetch user: no rows"
++ "load user: no rows"
+  └── "no rows"`
+	a := errx.Wrap("fetch user", errors.New("no rows"))
+	b := errx.Wrap("load user", errors.New("no rows"))
+	if diff := errxtest.Diff(a, b); diff != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, want)
+	}
+}
+
+func TestDiff_Causes(t *testing.T) {
+	a := errors.Join(errors.New("a"))
+	b := errors.Join(errors.New("a"), errx.Classify(errors.New("b"), ErrDatabase))
+
+	want := `- "a"
++ "a\nb"
+  ├── "a"
++ └── "b"
++       sentinels=[database]
++     └── "b"`
+	if diff := errxtest.Diff(a, b); diff != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, want)
+	}
+}
+
+func TestDiff_Nil(t *testing.T) {
+	want := `- <nil>
++ "no rows"`
+	if diff := errxtest.Diff(nil, errors.New("no rows")); diff != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, want)
+	}
+
+	want = `- "no rows"
++ <nil>`
+	if diff := errxtest.Diff(errors.New("no rows"), nil); diff != want {
+		t.Errorf("Diff() =\n%s\nwant\n%s", diff, want)
+	}
+}
+
+func TestDiff_AttributeOrder(t *testing.T) {
+	a := errx.Classify(errors.New("invalid"), errx.Attrs("a", 1, "b", 2))
+	b := errx.Classify(errors.New("invalid"), errx.Attrs("b", 2, "a", 1))
+	if diff := errxtest.Diff(a, b); diff != "" {
+		t.Errorf("expected attribute order to be ignored, got:\n%s", diff)
+	}
+}
+
+func TestDiff_FromSerialized(t *testing.T) {
+	data, err := errxjson.Marshal(sampleError())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var logged errxjson.SerializedError
+	if err := json.Unmarshal(data, &logged); err != nil {
+		t.Fatal(err)
+	}
+	rebuilt := errxtest.FromSerialized(&logged)
+
+	if rebuilt.Error() != sampleError().Error() {
+		t.Errorf("expected message %q, got %q", sampleError().Error(), rebuilt.Error())
+	}
+	if diff := errxtest.Diff(rebuilt, sampleError()); diff != "" {
+		t.Errorf("expected no diff after JSON round trip, got:\n%s", diff)
+	}
+	if errxtest.Tree(rebuilt) != errxtest.Tree(sampleError()) {
+		t.Errorf("expected equal trees, got:\n%s\nwant\n%s", errxtest.Tree(rebuilt), errxtest.Tree(sampleError()))
+	}
+	if !strings.Contains(errxtest.Diff(rebuilt, errx.Classify(sampleError(), ErrDatabase)), "+   sentinels=[database]") {
+		t.Error("expected rebuilt error to be compared structurally")
+	}
+
+	if errxtest.FromSerialized(nil) != nil {
+		t.Error("expected nil for nil serialized error")
+	}
+}
+
+func TestDiff_WithTraces(t *testing.T) {
+	if !stacktrace.Enabled {
+		t.Skip("stack traces are compiled out")
+	}
+	a := stacktrace.Wrap("failed", errors.New("root"))
+	b := stacktrace.Wrap("failed", errors.New("root"))
+
+	if diff := errxtest.Diff(a, b); diff != "" {
+		t.Errorf("expected traces to be ignored by default, got:\n%s", diff)
+	}
+	diff := errxtest.Diff(a, b, errxtest.WithTraces())
+	if !strings.Contains(diff, "-   trace=github.com/go-extras/errx/errxtest_test.TestDiff_WithTraces (diff_test.go:") {
+		t.Errorf("expected trace diff, got:\n%s", diff)
+	}
+}
@@ -0,0 +1,52 @@
+// Package errxtest provides test helpers for errx errors.
+//
+// The assertion helpers accept testing.TB, so they work in tests, benchmarks
+// and fuzz targets. On failure they report what was expected together with the
+// full errx tree of the error: the message of every layer in the chain, and the
+// sentinels, display text, attributes and stack trace attached at each layer.
+//
+// # Assertions
+//
+//	err := service.FetchUser(ctx, "42")
+//	errxtest.AssertIs(t, err, ErrNotFound)
+//	errxtest.AssertDisplayText(t, err, "User not found")
+//	errxtest.AssertAttr(t, err, "user_id", "42")
+//
+// A failing assertion reports, for example:
+//
+//	errors.Is(err, not found) = false
+//	error tree:
+//	"fetch user: connection refused" sentinels=[database]
+//	└── "connection refused"
+//
+// # Chain Assertions
+//
+// AssertChain compares the whole tree against an expected shape:
+//
+//	errxtest.AssertChain(t, err, errxtest.Node{
+//	    Message:   "fetch user: no rows",
+//	    Sentinels: []errx.Classified{ErrNotFound},
+//	    Causes: []errxtest.Node{
+//	        {Message: "no rows"},
+//	    },
+//	})
+//
+// # Golden Files
+//
+// Golden compares the JSON serialization of an error with a golden file under
+// testdata, normalized so that stack traces and attribute order don't make the
+// comparison flaky. Run the tests with ERRXTEST_UPDATE=1 to write the golden files:
+//
+//	errxtest.Golden(t, "fetch_user_not_found", err)
+//
+// # Structural Diffs
+//
+// Diff compares two errors layer by layer, including their classifications, and
+// FromSerialized rebuilds errors from their JSON serialization for comparison:
+//
+//	if diff := errxtest.Diff(want, got); diff != "" {
+//	    t.Errorf("error mismatch (-want +got):\n%s", diff)
+//	}
+//
+// All helpers return whether the assertion passed and never stop the test.
+package errxtest
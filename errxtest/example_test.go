@@ -0,0 +1,45 @@
+package errxtest_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/errxtest"
+)
+
+// ExampleTree demonstrates rendering the errx tree of an error
+func ExampleTree() {
+	var ErrNotFound = errx.NewSentinel("not found")
+
+	root := errx.Classify(errors.New("no rows"), errx.NewDisplayable("User not found"), errx.Attrs("user_id", 42))
+	err := errx.Wrap("fetch user", root, ErrNotFound)
+
+	fmt.Println(errxtest.Tree(err))
+
+	// Output:
+	// "fetch user: no rows" sentinels=[not found]
+	// └── "no rows" display="User not found" attrs=[user_id=42]
+	//     └── "no rows"
+}
+
+// ExampleDiff demonstrates comparing two errors structurally
+func ExampleDiff() {
+	var (
+		ErrNotFound = errx.NewSentinel("not found")
+		ErrDatabase = errx.NewSentinel("database")
+	)
+
+	before := errx.Wrap("fetch user", errors.New("no rows"), ErrNotFound)
+	after := errx.Wrap("fetch user", errors.New("no rows"), ErrDatabase)
+
+	fmt.Println("Same message:", before.Error() == after.Error())
+	fmt.Println(errxtest.Diff(before, after))
+
+	// Output:
+	// Same message: true
+	//   "fetch user: no rows"
+	// -   sentinels=[not found]
+	// +   sentinels=[database]
+	//   └── "no rows"
+}
@@ -0,0 +1,178 @@
+package errxtest
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// UpdateEnv is the environment variable that makes Golden write golden files
+// instead of comparing with them, when set to a true value such as "1".
+// An environment variable is used rather than a flag, so that it doesn't clash
+// with flags defined by test packages and applies to all packages of a go test run.
+const UpdateEnv = "ERRXTEST_UPDATE"
+
+// updating reports whether golden files should be written (see UpdateEnv).
+func updating() bool {
+	update, _ := strconv.ParseBool(os.Getenv(UpdateEnv))
+	return update
+}
+
+// addressPattern matches hexadecimal memory addresses, such as those printed for pointers.
+var addressPattern = regexp.MustCompile(`0x[0-9a-fA-F]{6,}`)
+
+// Golden compares the normalized JSON serialization of err with the golden file
+// testdata/<name>.golden, relative to the test's working directory. When the
+// ERRXTEST_UPDATE environment variable is set to a true value, the golden file is
+// written instead:
+//
+//	ERRXTEST_UPDATE=1 go test ./... -run TestFetchUser
+//
+// The serialization is normalized so that golden files are stable across runs,
+// machines and unrelated code changes:
+//   - attributes are sorted by key, then by value
+//   - stack frame paths are relative to the module root, or reduced to their
+//     directory and file name outside the module (such as in GOROOT)
+//   - stack frame line numbers are masked as 0
+//   - runtime frames (such as runtime.goexit, whose file depends on the
+//     architecture) are removed
+//   - memory addresses (0x followed by at least 6 hex digits) are replaced by 0xADDR
+//
+// Golden returns whether err matched the golden file.
+func Golden(t testing.TB, name string, err error) bool {
+	t.Helper()
+
+	got, marshalErr := normalizedJSON(err)
+	if marshalErr != nil {
+		t.Errorf("errxtest: serializing error: %v", marshalErr)
+		return false
+	}
+
+	path := filepath.Join("testdata", filepath.FromSlash(name)+".golden")
+	if updating() {
+		if mkdirErr := os.MkdirAll(filepath.Dir(path), 0o755); mkdirErr != nil {
+			t.Errorf("errxtest: creating golden file directory: %v", mkdirErr)
+			return false
+		}
+		if writeErr := os.WriteFile(path, got, 0o644); writeErr != nil { //nolint:gosec // golden files are meant to be readable
+			t.Errorf("errxtest: writing golden file: %v", writeErr)
+			return false
+		}
+		return true
+	}
+
+	want, readErr := os.ReadFile(path)
+	switch {
+	case errors.Is(readErr, fs.ErrNotExist):
+		t.Errorf("errxtest: golden file %s does not exist (run the test with "+UpdateEnv+"=1 to create it)", path)
+		return false
+	case readErr != nil:
+		t.Errorf("errxtest: reading golden file: %v", readErr)
+		return false
+	}
+
+	if bytes.Equal(got, want) {
+		return true
+	}
+	t.Errorf("errxtest: error does not match golden file %s (run the test with "+UpdateEnv+"=1 to accept it)\ngot:\n%s\nwant:\n%s\nerror tree:\n%s",
+		path, got, want, Tree(err))
+	return false
+}
+
+// normalizedJSON serializes err and normalizes the result for golden files.
+func normalizedJSON(err error) ([]byte, error) {
+	serialized := errxjson.ToSerializedError(err)
+	if serialized != nil {
+		normalize(serialized, moduleRoot())
+	}
+
+	data, marshalErr := json.MarshalIndent(serialized, "", "  ")
+	if marshalErr != nil {
+		return nil, marshalErr
+	}
+	data = addressPattern.ReplaceAll(data, []byte("0xADDR"))
+	return append(data, '\n'), nil
+}
+
+// normalize rewrites a serialized error and its causes in place,
+// removing details that vary between runs and machines.
+func normalize(s *errxjson.SerializedError, root string) {
+	slices.SortStableFunc(s.Attributes, func(a, b errxjson.SerializedAttr) int {
+		return cmp.Or(cmp.Compare(a.Key, b.Key), cmp.Compare(fmt.Sprint(a.Value), fmt.Sprint(b.Value)))
+	})
+
+	s.StackTrace = normalizeFrames(s.StackTrace, root)
+	for i, ancestor := range s.GoroutineAncestors {
+		s.GoroutineAncestors[i] = normalizeFrames(ancestor, root)
+	}
+
+	if s.Cause != nil {
+		normalize(s.Cause, root)
+	}
+	for _, cause := range s.Causes {
+		if cause != nil {
+			normalize(cause, root)
+		}
+	}
+}
+
+// normalizeFrames removes runtime frames, relativizes frame paths and masks
+// line numbers.
+func normalizeFrames(frames []errxjson.SerializedFrame, root string) []errxjson.SerializedFrame {
+	frames = slices.DeleteFunc(frames, func(frame errxjson.SerializedFrame) bool {
+		return strings.HasPrefix(frame.Function, "runtime.")
+	})
+	for i := range frames {
+		frames[i].File = relativePath(frames[i].File, root)
+		frames[i].Line = 0
+		for j := range frames[i].Context {
+			frames[i].Context[j].Line = 0
+		}
+	}
+	return frames
+}
+
+// relativePath returns file relative to root, or its directory and file name
+// if it is outside root. The result always uses forward slashes.
+func relativePath(file, root string) string {
+	if file == "" {
+		return file
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root, file); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(filepath.Join(filepath.Base(filepath.Dir(file)), filepath.Base(file)))
+}
+
+// moduleRoot returns the nearest directory containing a go.mod file, starting
+// from the working directory, or the working directory if there is none.
+func moduleRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for dir := wd; ; {
+		if _, statErr := os.Stat(filepath.Join(dir, "go.mod")); statErr == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return wd
+		}
+		dir = parent
+	}
+}
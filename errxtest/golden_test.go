@@ -0,0 +1,99 @@
+package errxtest_test
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/errxtest"
+)
+
+// Test packages using Golden can define the common -update flag for their own golden files
+var _ = flag.Bool("update", false, "update golden files")
+
+func TestGolden(t *testing.T) {
+	errxtest.Golden(t, "sample", sampleError())
+}
+
+func TestGolden_AttrMapOrder(t *testing.T) {
+	// Map iteration order differs between runs, attribute order in the golden file must not
+	for range 10 {
+		err := errx.Classify(errors.New("invalid request"), errx.FromAttrMap(errx.AttrMap{
+			"method": "POST",
+			"path":   "/users",
+			"status": 400,
+			"user":   "alice",
+		}))
+		errxtest.Golden(t, "attr_map", err)
+	}
+}
+
+func TestGolden_MemoryAddresses(t *testing.T) {
+	var value int
+	err := errx.Wrap(fmt.Sprintf("invalid pointer %p", &value), errors.New("bad state"), ErrDatabase)
+	errxtest.Golden(t, "addresses", err)
+}
+
+func TestGolden_Mismatch(t *testing.T) {
+	setUpdate(t, false)
+
+	r := &recorder{TB: t}
+	if errxtest.Golden(r, "sample", errx.Wrap("fetch user", errors.New("no rows"), ErrDatabase)) {
+		t.Fatal("expected mismatch")
+	}
+	out := r.output()
+	for _, want := range []string{
+		"does not match golden file testdata/sample.golden",
+		"ERRXTEST_UPDATE=1",
+		`"message": "fetch user: no rows"`,
+		"error tree:",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestGolden_Missing(t *testing.T) {
+	setUpdate(t, false)
+
+	r := &recorder{TB: t}
+	if errxtest.Golden(r, "does_not_exist", sampleError()) {
+		t.Fatal("expected failure")
+	}
+	if !strings.Contains(r.output(), "golden file testdata/does_not_exist.golden does not exist") {
+		t.Errorf("unexpected output:\n%s", r.output())
+	}
+}
+
+func TestGolden_Update(t *testing.T) {
+	t.Chdir(t.TempDir())
+	setUpdate(t, true)
+
+	if !errxtest.Golden(t, "nested/sample", sampleError()) {
+		t.Fatal("expected update to succeed")
+	}
+	data, err := os.ReadFile(filepath.Join("testdata", "nested", "sample.golden"))
+	if err != nil {
+		t.Fatalf("reading written golden file: %v", err)
+	}
+	if !strings.Contains(string(data), `"message": "fetch user: no rows"`) {
+		t.Errorf("unexpected golden file content:\n%s", data)
+	}
+}
+
+// setUpdate sets the update environment variable for the duration of a test
+func setUpdate(t *testing.T, update bool) {
+	t.Helper()
+
+	if update {
+		t.Setenv(errxtest.UpdateEnv, "1")
+	} else {
+		t.Setenv(errxtest.UpdateEnv, "")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !errx_notrace
+
+package errxtest_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx/errxtest"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestGolden_StackTrace(t *testing.T) {
+	err := stacktrace.Wrap("query failed", errors.New("connection reset"), ErrDatabase)
+	errxtest.Golden(t, "stack_trace", err)
+}
@@ -0,0 +1,188 @@
+package errxtest
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// layer is one error of an errx tree, holding what was attached at that layer.
+//
+// The json package reports display text, attributes and stack traces as seen from
+// each layer, which includes everything attached further down the chain. A layer
+// only keeps what its causes don't already report.
+type layer struct {
+	message   string
+	display   string
+	sentinels []string
+	attrs     []errxjson.SerializedAttr
+	trace     []errxjson.SerializedFrame
+	causes    []*layer
+	err       error // The error the layer was built from; nil for serialized input
+}
+
+// buildTree converts an error into its tree of layers.
+// It returns nil for nil errors.
+func buildTree(err error) *layer {
+	if err == nil {
+		return nil
+	}
+	if rebuilt, ok := err.(*serializedError); ok {
+		return newLayer(rebuilt.s, false)
+	}
+	return newLayer(errxjson.ToSerializedError(err), true)
+}
+
+// newLayer converts a serialized error into a layer, keeping only what is
+// attached at this layer. live reports whether s was serialized from an error
+// in this process, whose sentinels can be matched by identity.
+func newLayer(s *errxjson.SerializedError, live bool) *layer {
+	l := &layer{
+		message:   s.Message,
+		display:   s.DisplayText,
+		sentinels: s.Sentinels,
+		attrs:     s.Attributes,
+		trace:     s.StackTrace,
+	}
+	if live {
+		l.err = s.Err
+	}
+
+	children := s.Causes
+	if s.Cause != nil {
+		children = []*errxjson.SerializedError{s.Cause}
+	}
+
+	var (
+		childDisplays []string
+		childAttrs    []errxjson.SerializedAttr
+		childTraces   [][]errxjson.SerializedFrame
+	)
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		l.causes = append(l.causes, newLayer(child, live))
+		childDisplays = append(childDisplays, child.DisplayText)
+		childAttrs = append(childAttrs, child.Attributes...)
+		childTraces = append(childTraces, child.StackTrace)
+	}
+
+	if slices.Contains(childDisplays, l.display) {
+		l.display = ""
+	}
+	l.attrs = subtractAttrs(l.attrs, childAttrs)
+	for _, trace := range childTraces {
+		if sameTrace(l.trace, trace) {
+			l.trace = nil
+			break
+		}
+	}
+	return l
+}
+
+// subtractAttrs returns the attributes of attrs that are not in other,
+// counting duplicates.
+func subtractAttrs(attrs, other []errxjson.SerializedAttr) []errxjson.SerializedAttr {
+	if len(attrs) == 0 {
+		return nil
+	}
+	remaining := make(map[string]int, len(other))
+	for _, attr := range other {
+		remaining[attrString(attr)]++
+	}
+	var result []errxjson.SerializedAttr
+	for _, attr := range attrs {
+		key := attrString(attr)
+		if remaining[key] > 0 {
+			remaining[key]--
+			continue
+		}
+		result = append(result, attr)
+	}
+	return result
+}
+
+// sameTrace reports whether two traces have the same frames.
+func sameTrace(a, b []errxjson.SerializedFrame) bool {
+	return slices.EqualFunc(a, b, func(x, y errxjson.SerializedFrame) bool {
+		return x.File == y.File && x.Line == y.Line && x.Function == y.Function
+	})
+}
+
+// attrString formats an attribute as key=value.
+func attrString(attr errxjson.SerializedAttr) string {
+	return fmt.Sprintf("%s=%+v", attr.Key, attr.Value)
+}
+
+// frameString formats a stack frame with the base name of its file.
+func frameString(frame errxjson.SerializedFrame) string {
+	return fmt.Sprintf("%s (%s:%d)", frame.Function, filepath.Base(frame.File), frame.Line)
+}
+
+// Tree renders the errx tree of err for humans: one line per layer of the chain,
+// with the sentinels, display text, attributes and top stack frame attached at
+// that layer. Multi-errors branch into one subtree per error.
+//
+// Example output:
+//
+//	"handler: fetch user: no rows" trace=main.handler (main.go:42)
+//	└── "fetch user: no rows" sentinels=[not found]
+//	    └── "no rows" display="User not found" attrs=[user_id=42]
+//
+// Tree returns "<nil>" for nil errors.
+func Tree(err error) string {
+	var b strings.Builder
+	writeTree(&b, buildTree(err))
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// writeTree renders a layer tree, used for both Tree and failure output.
+func writeTree(b *strings.Builder, root *layer) {
+	if root == nil {
+		b.WriteString("<nil>\n")
+		return
+	}
+	writeLayer(b, root, "", "")
+}
+
+// writeLayer renders a layer and its causes. prefix is written before the layer's
+// own line, and indent before the lines of its causes.
+func writeLayer(b *strings.Builder, l *layer, prefix, indent string) {
+	b.WriteString(prefix)
+	b.WriteString(describeLayer(l))
+	b.WriteByte('\n')
+
+	for i, cause := range l.causes {
+		if i == len(l.causes)-1 {
+			writeLayer(b, cause, indent+"└── ", indent+"    ")
+		} else {
+			writeLayer(b, cause, indent+"├── ", indent+"│   ")
+		}
+	}
+}
+
+// describeLayer formats a single layer on one line.
+func describeLayer(l *layer) string {
+	parts := []string{fmt.Sprintf("%q", l.message)}
+	if len(l.sentinels) > 0 {
+		parts = append(parts, "sentinels=["+strings.Join(l.sentinels, ", ")+"]")
+	}
+	if l.display != "" {
+		parts = append(parts, fmt.Sprintf("display=%q", l.display))
+	}
+	if len(l.attrs) > 0 {
+		attrs := make([]string, len(l.attrs))
+		for i, attr := range l.attrs {
+			attrs[i] = attrString(attr)
+		}
+		parts = append(parts, "attrs=["+strings.Join(attrs, " ")+"]")
+	}
+	if len(l.trace) > 0 {
+		parts = append(parts, "trace="+frameString(l.trace[0]))
+	}
+	return strings.Join(parts, " ")
+}
@@ -513,3 +513,165 @@ func ExampleAttrList_ToSlogArgs() {
 	// Output:
 	// level=ERROR msg="operation failed" user_id=123 action=delete resource=account
 }
+
+// ExampleContextWithAttrs demonstrates attaching request metadata carried by a context
+func ExampleContextWithAttrs() {
+	var ErrNotFound = errx.NewSentinel("not found")
+
+	// Typically done once, in a middleware
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-42", "tenant", "acme")
+
+	inner := errx.ClassifyCtx(ctx, errors.New("no rows"), ErrNotFound)
+	err := errx.WrapCtx(ctx, "failed to load order", inner)
+
+	fmt.Println(err.Error())
+	fmt.Println(errx.ExtractAttrs(err))
+
+	// Output:
+	// failed to load order: no rows
+	// request_id=req-42 tenant=acme
+}
+
+// ExampleSentinels demonstrates listing the sentinels attached to an error chain
+func ExampleSentinels() {
+	ErrDatabase := errx.NewSentinel("database")
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase)
+	ErrRetryable := errx.NewSentinel("retryable")
+
+	err := errx.Wrap("load user", errx.Classify(errors.New("i/o timeout"), ErrTimeout), ErrRetryable)
+	for _, s := range errx.Sentinels(err) {
+		fmt.Println(s)
+	}
+
+	// Output:
+	// retryable
+	// timeout
+}
+
+// ExampleSeverityOf demonstrates logging errors at the severity of their sentinels
+func ExampleSeverityOf() {
+	ErrNotFound := errx.NewSentinel("not found", errx.Severity(slog.LevelInfo))
+	ErrDatabase := errx.NewSentinel("database", errx.Severity(slog.LevelWarn))
+	ErrDatabaseCorrupt := errx.NewSentinel("database corrupt", ErrDatabase, errx.Severity(slog.LevelError))
+	ErrDatabaseTimeout := errx.NewSentinel("database timeout", ErrDatabase)
+
+	fmt.Println(errx.SeverityOf(errx.Classify(errors.New("no rows"), ErrNotFound)))
+	fmt.Println(errx.SeverityOf(errx.Classify(errors.New("bad page"), ErrDatabaseCorrupt)))
+	fmt.Println(errx.SeverityOf(errx.Classify(errors.New("i/o timeout"), ErrDatabaseTimeout)))
+	fmt.Println(errx.SeverityOf(errors.New("unclassified")))
+
+	// Output:
+	// INFO
+	// ERROR
+	// WARN
+	// ERROR
+}
+
+// ExampleMatch demonstrates mapping errors to HTTP statuses by their most specific sentinel
+func ExampleMatch() {
+	ErrDatabase := errx.NewSentinel("database")
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase)
+	ErrNotFound := errx.NewSentinel("not found")
+
+	statusOf := func(err error) int {
+		status := 500
+		errx.Match(err).
+			Case(ErrDatabase, func(error) { status = 503 }).
+			Case(ErrTimeout, func(error) { status = 504 }). // more specific than ErrDatabase
+			Case(ErrNotFound, func(error) { status = 404 }).
+			Default(func(error) { status = 500 })
+		return status
+	}
+
+	fmt.Println(statusOf(errx.Classify(errors.New("i/o timeout"), ErrTimeout)))
+	fmt.Println(statusOf(errx.Classify(errors.New("connection refused"), ErrDatabase)))
+	fmt.Println(statusOf(errx.Classify(errors.New("no rows"), ErrNotFound)))
+	fmt.Println(statusOf(errors.New("unexpected")))
+
+	// Output:
+	// 504
+	// 503
+	// 404
+	// 500
+}
+
+// ExampleMostSpecific demonstrates picking the most specific matching sentinel
+func ExampleMostSpecific() {
+	ErrDatabase := errx.NewSentinel("database")
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase)
+
+	err := errx.Classify(errors.New("i/o timeout"), ErrTimeout)
+	fmt.Println(errx.MostSpecific(err, ErrDatabase, ErrTimeout))
+
+	// Output:
+	// timeout
+}
+
+// ExampleAncestors demonstrates inspecting a sentinel hierarchy
+func ExampleAncestors() {
+	ErrServer := errx.NewSentinel("server")
+	ErrRetryable := errx.NewSentinel("retryable")
+	ErrDatabase := errx.NewSentinel("database", ErrServer)
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase, ErrRetryable)
+
+	fmt.Println(errx.Parents(ErrTimeout))
+	fmt.Println(errx.Ancestors(ErrTimeout))
+	fmt.Println(errx.IsDescendant(ErrTimeout, ErrServer))
+
+	// Output:
+	// [database retryable]
+	// [database server retryable]
+	// true
+}
+
+// ExampleHierarchyMermaid demonstrates rendering a sentinel hierarchy for documentation
+func ExampleHierarchyMermaid() {
+	ErrServer := errx.NewSentinel("server")
+	ErrDatabase := errx.NewSentinel("database", ErrServer)
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase)
+	ErrNotFound := errx.NewSentinel("not found")
+
+	fmt.Print(errx.HierarchyMermaid(ErrTimeout, ErrNotFound))
+
+	// Output:
+	// graph BT
+	// 	n0["timeout"]
+	// 	n1["not found"]
+	// 	n2["database"]
+	// 	n3["server"]
+	// 	n0 --> n2
+	// 	n2 --> n3
+}
+
+// ExampleDisplayTextFor demonstrates showing each audience its own message for the same error
+func ExampleDisplayTextFor() {
+	ErrPaymentDeclined := errx.NewDisplayableFor("Payment failed", map[errx.Audience]string{
+		errx.AudienceCustomer: "Your card was declined",
+		errx.AudienceSupport:  "Card declined by issuer (insufficient funds)",
+	})
+
+	err := errx.Wrap("charge order", errors.New("issuer response 51"), ErrPaymentDeclined)
+	fmt.Println(errx.DisplayTextFor(err, errx.AudiencePublic))
+	fmt.Println(errx.DisplayTextFor(err, errx.AudienceCustomer))
+	fmt.Println(errx.DisplayTextFor(err, errx.AudienceSupport))
+
+	// Output:
+	// Payment failed
+	// Your card was declined
+	// Card declined by issuer (insufficient funds)
+}
+
+// ExampleFingerprint demonstrates grouping occurrences of the same error that differ only in their values
+func ExampleFingerprint() {
+	ErrNotFound := errx.NewSentinel("not found")
+	load := func(id int) error {
+		return errx.Wrap(fmt.Sprintf("load order %d", id), errors.New("no rows"), ErrNotFound, errx.Attrs("order_id", id))
+	}
+
+	fmt.Println(errx.Fingerprint(load(42)) == errx.Fingerprint(load(1337)))
+	fmt.Println(errx.Fingerprint(load(42)) == errx.Fingerprint(errx.Wrap("save order", errors.New("no rows"), ErrNotFound)))
+
+	// Output:
+	// true
+	// false
+}
@@ -108,3 +108,36 @@ func TestExternalClassifiedMarkerMethod(t *testing.T) {
 		t.Error("expected IsClassified to return true through interface")
 	}
 }
+
+// timeoutClassified is an external classification that reports timeout behavior
+type timeoutClassified struct{}
+
+func (timeoutClassified) Error() string      { return "timeout" }
+func (timeoutClassified) IsClassified() bool { return true }
+func (timeoutClassified) Timeout() bool      { return true }
+
+// TestExternalClassifiedWithBehavior verifies that classifications implementing
+// Timeout() make the classified error implement it too
+func TestExternalClassifiedWithBehavior(t *testing.T) {
+	err := errx.Classify(errors.New("slow query"), timeoutClassified{}, errx.Attrs("table", "users"))
+
+	to, ok := err.(interface{ Timeout() bool })
+	if !ok || !to.Timeout() {
+		t.Error("expected classified error to report Timeout() == true")
+	}
+	tmp, ok := err.(interface{ Temporary() bool })
+	if !ok || tmp.Temporary() {
+		t.Error("expected classified error to report Temporary() == false")
+	}
+	if !errors.Is(err, timeoutClassified{}) {
+		t.Error("expected errors.Is to match the classification")
+	}
+	if len(errx.ExtractAttrs(err)) != 1 {
+		t.Error("expected attributes to be extracted from the classified error")
+	}
+
+	plain := errx.Classify(errors.New("slow query"), errx.NewSentinel("slow"))
+	if _, ok := plain.(interface{ Timeout() bool }); ok {
+		t.Error("expected plain classified error not to implement Timeout()")
+	}
+}
@@ -0,0 +1,273 @@
+package errx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Fingerprinter computes stable fingerprints of errors, so that occurrences of the
+// same logical error can be grouped by error trackers and alert dedupers.
+// Create one per component with NewFingerprinter, or use Fingerprint for the defaults.
+// A Fingerprinter is immutable and safe for concurrent use.
+type Fingerprinter struct {
+	frames    int
+	message   bool
+	libraries []string
+}
+
+// FingerprintOption configures a Fingerprinter.
+type FingerprintOption func(*Fingerprinter)
+
+// WithFingerprintFrames sets how many of the topmost non-library stack frames are
+// part of the fingerprint. The default is 3; zero leaves stack traces out.
+func WithFingerprintFrames(n int) FingerprintOption {
+	return func(f *Fingerprinter) {
+		f.frames = n
+	}
+}
+
+// WithFingerprintMessage sets whether the message skeleton is part of the fingerprint.
+// The default is true. Leave it out to group only by sentinels and stack frames.
+func WithFingerprintMessage(include bool) FingerprintOption {
+	return func(f *Fingerprinter) {
+		f.message = include
+	}
+}
+
+// WithLibraryPrefixes adds function name prefixes, such as "github.com/jackc/pgx/",
+// whose stack frames are skipped as library frames. The Go standard library and errx
+// itself are always skipped.
+func WithLibraryPrefixes(prefixes ...string) FingerprintOption {
+	return func(f *Fingerprinter) {
+		f.libraries = append(slices.Clip(f.libraries), prefixes...)
+	}
+}
+
+// NewFingerprinter returns a Fingerprinter configured with the given options.
+func NewFingerprinter(opts ...FingerprintOption) *Fingerprinter {
+	f := &Fingerprinter{
+		frames:  3,
+		message: true,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+var defaultFingerprinter = NewFingerprinter()
+
+// Fingerprint returns a stable fingerprint of err with the default configuration.
+// See Fingerprinter.Fingerprint.
+//
+// Example:
+//
+//	alerts.Dedupe(errx.Fingerprint(err), err)
+func Fingerprint(err error) string {
+	return defaultFingerprinter.Fingerprint(err)
+}
+
+// Fingerprint returns a stable fingerprint of err: a 32-character hex string hashing
+//   - the texts of the sentinels attached to the error (see Sentinels), in sorted order
+//   - the message skeleton: the error message with the string values of its
+//     attributes replaced by their keys and all words containing digits (numbers,
+//     IDs, addresses) replaced by "#"
+//   - the function names of the topmost non-library frames of its stack trace
+//
+// Stack traces are read from the classifications of the stacktrace package and from
+// errors implementing Callers() []uintptr, without importing them. Line numbers are
+// not used, so fingerprints survive unrelated edits to the source.
+//
+// Returns "" for nil errors.
+func (f *Fingerprinter) Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	h := sha256.New()
+	write := func(kind, value string) {
+		fmt.Fprintf(h, "%s\x00%s\x00", kind, value)
+	}
+
+	var codes []string
+	for _, s := range Sentinels(err) {
+		codes = append(codes, s.Error())
+	}
+	slices.Sort(codes)
+	for _, code := range slices.Compact(codes) {
+		write("sentinel", code)
+	}
+	if f.message {
+		write("message", messageSkeleton(err.Error(), ExtractAttrs(err)))
+	}
+	for _, fn := range f.topFrames(err) {
+		write("frame", fn)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
+
+// topFrames returns the function names of the topmost non-library frames of the
+// first stack trace in err's chain.
+func (f *Fingerprinter) topFrames(err error) []string {
+	if f.frames <= 0 {
+		return nil
+	}
+	var c interface{ Callers() []uintptr }
+	if !errors.As(err, &c) {
+		return nil
+	}
+	pcs := c.Callers()
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	var result []string
+	frames := runtime.CallersFrames(pcs)
+	for len(result) < f.frames {
+		frame, more := frames.Next()
+		if frame.Function != "" && !f.isLibrary(frame.Function) {
+			result = append(result, frame.Function)
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+// isLibrary reports whether a function belongs to the standard library, to errx
+// or to one of the configured library prefixes.
+func (f *Fingerprinter) isLibrary(function string) bool {
+	if strings.HasPrefix(function, "github.com/go-extras/errx.") ||
+		strings.HasPrefix(function, "github.com/go-extras/errx/") {
+		return true
+	}
+	for _, prefix := range f.libraries {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+	return isStdlib(function)
+}
+
+// modulePaths returns the paths of the main module and its dependencies, read once
+// from the build information of the binary.
+var modulePaths = sync.OnceValue(func() []string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	paths := []string{info.Main.Path}
+	for _, dep := range info.Deps {
+		paths = append(paths, dep.Path)
+	}
+	return paths
+})
+
+// isStdlib reports whether a function belongs to the Go standard library. Standard
+// library import paths have no dot in their first element, but module paths may lack
+// one as well (such as "module myservice"), so functions of the main module and its
+// dependencies are excluded using the build information. File paths can't be used
+// instead, since -trimpath makes them relative.
+func isStdlib(function string) bool {
+	first := function
+	if i := strings.IndexByte(first, '/'); i >= 0 {
+		first = first[:i]
+	} else if i := strings.IndexByte(first, '.'); i >= 0 {
+		first = first[:i]
+	}
+	if first == "main" || strings.Contains(first, ".") {
+		return false
+	}
+	for _, path := range modulePaths() {
+		if path != "" && (strings.HasPrefix(function, path+"/") || strings.HasPrefix(function, path+".")) {
+			return false
+		}
+	}
+	return true
+}
+
+// messageSkeleton strips the variable parts of an error message: the string values
+// of attributes are replaced by their keys, then words containing digits by "#".
+func messageSkeleton(msg string, attrs AttrList) string {
+	// Longer values first, so that a value containing another one is replaced whole
+	values := make([]Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		if s := fmt.Sprint(attr.Value); len(s) > 1 {
+			values = append(values, Attr{Key: attr.Key, Value: s})
+		}
+	}
+	slices.SortStableFunc(values, func(a, b Attr) int {
+		return len(b.Value.(string)) - len(a.Value.(string))
+	})
+	for _, attr := range values {
+		msg = replaceWord(msg, attr.Value.(string), "<"+attr.Key+">")
+	}
+
+	var b strings.Builder
+	for len(msg) > 0 {
+		end := wordEnd(msg)
+		if end == 0 {
+			_, size := utf8.DecodeRuneInString(msg)
+			b.WriteString(msg[:size])
+			msg = msg[size:]
+			continue
+		}
+		word := msg[:end]
+		if strings.ContainsFunc(word, unicode.IsDigit) {
+			word = "#"
+		}
+		b.WriteString(word)
+		msg = msg[end:]
+	}
+	return b.String()
+}
+
+// replaceWord replaces the occurrences of old in s that are not part of a longer word.
+func replaceWord(s, old, replacement string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(s, old)
+		if i < 0 {
+			b.WriteString(s)
+			return b.String()
+		}
+		end := i + len(old)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (i > 0 && isWordRune(before) && isWordRune(rune(old[0]))) ||
+			(end < len(s) && isWordRune(after) && isWordRune(rune(old[len(old)-1]))) {
+			b.WriteString(s[:end])
+		} else {
+			b.WriteString(s[:i])
+			b.WriteString(replacement)
+		}
+		s = s[end:]
+	}
+}
+
+// wordEnd returns the length of the word at the start of s, or 0 if s does not start with one.
+// Words are runs of letters, digits and the characters commonly found in identifiers.
+func wordEnd(s string) int {
+	for i, r := range s {
+		if !isWordRune(r) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// isWordRune reports whether r can be part of a word in a message skeleton.
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-'
+}
@@ -0,0 +1,85 @@
+package errx_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-extras/errx"
+)
+
+func TestFingerprint_Stable(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	load := func(id int, user string) error {
+		return errx.Wrap(fmt.Sprintf("load order %d for %s", id, user),
+			errors.New("no rows"), ErrNotFound, errx.Attrs("user", user))
+	}
+
+	a := errx.Fingerprint(load(42, "alice"))
+	b := errx.Fingerprint(load(1337, "bob"))
+	if a != b {
+		t.Errorf("expected equal fingerprints for the same error with different values, got %s and %s", a, b)
+	}
+	if len(a) != 32 {
+		t.Errorf("expected 32 hex characters, got %q", a)
+	}
+}
+
+func TestFingerprint_Differs(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	ErrConflict := errx.NewSentinel("conflict")
+	base := errx.Fingerprint(errx.Wrap("load order", errors.New("no rows"), ErrNotFound))
+
+	for name, err := range map[string]error{
+		"sentinel": errx.Wrap("load order", errors.New("no rows"), ErrConflict),
+		"message":  errx.Wrap("save order", errors.New("no rows"), ErrNotFound),
+		"none":     errx.Wrap("load order", errors.New("no rows")),
+	} {
+		if errx.Fingerprint(err) == base {
+			t.Errorf("%s: expected a different fingerprint", name)
+		}
+	}
+}
+
+func TestFingerprint_SentinelOrder(t *testing.T) {
+	ErrA := errx.NewSentinel("a")
+	ErrB := errx.NewSentinel("b")
+	cause := errors.New("failed")
+
+	if errx.Fingerprint(errx.Classify(cause, ErrA, ErrB)) != errx.Fingerprint(errx.Classify(cause, ErrB, ErrA)) {
+		t.Error("expected the order of sentinels not to matter")
+	}
+}
+
+func TestFingerprint_AttributeValues(t *testing.T) {
+	// Attribute values are stripped as whole words only
+	withAttr := func(host string) error {
+		return errx.Classify(fmt.Errorf("dial %s: connection refused", host), errx.Attrs("host", host))
+	}
+	if errx.Fingerprint(withAttr("db.internal")) != errx.Fingerprint(withAttr("cache.internal")) {
+		t.Error("expected attribute values to be stripped")
+	}
+
+	a := errx.Classify(errors.New("connection refused"), errx.Attrs("state", "on"))
+	b := errx.Classify(errors.New("connectiXn refused"), errx.Attrs("state", "on"))
+	if errx.Fingerprint(a) == errx.Fingerprint(b) {
+		t.Error("expected different messages to differ")
+	}
+}
+
+func TestFingerprint_WithoutMessage(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	f := errx.NewFingerprinter(errx.WithFingerprintMessage(false))
+
+	a := f.Fingerprint(errx.Classify(errors.New("order missing"), ErrNotFound))
+	b := f.Fingerprint(errx.Classify(errors.New("user missing"), ErrNotFound))
+	if a != b {
+		t.Error("expected messages to be ignored")
+	}
+}
+
+func TestFingerprint_Nil(t *testing.T) {
+	if errx.Fingerprint(nil) != "" {
+		t.Error("expected empty fingerprint for nil error")
+	}
+}
@@ -0,0 +1,121 @@
+//go:build !errx_notrace
+
+package errx_test
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+//go:noinline
+func fingerprintFromA() error {
+	return stacktrace.Wrap("failed", errors.New("cause"))
+}
+
+//go:noinline
+func fingerprintFromB() error {
+	return stacktrace.Wrap("failed", errors.New("cause"))
+}
+
+func TestFingerprint_Frames(t *testing.T) {
+	a := errx.Fingerprint(fingerprintFromA())
+	if a != errx.Fingerprint(fingerprintFromA()) {
+		t.Error("expected equal fingerprints for the same location")
+	}
+	if a == errx.Fingerprint(fingerprintFromB()) {
+		t.Error("expected the stack trace to be part of the fingerprint")
+	}
+
+	f := errx.NewFingerprinter(errx.WithFingerprintFrames(0))
+	if f.Fingerprint(fingerprintFromA()) != f.Fingerprint(fingerprintFromB()) {
+		t.Error("expected stack traces to be ignored without frames")
+	}
+}
+
+func TestFingerprint_LibraryPrefixes(t *testing.T) {
+	// Treating the test package as a library leaves only the testing frames, which are skipped
+	f := errx.NewFingerprinter(errx.WithLibraryPrefixes("github.com/go-extras/errx_test."))
+	if f.Fingerprint(fingerprintFromA()) != f.Fingerprint(fingerprintFromB()) {
+		t.Error("expected library frames to be skipped")
+	}
+}
+
+// dotlessModule is a service whose module path has no dot, like the standard library
+var dotlessModule = map[string]string{
+	"go.mod": "module myservice\n\ngo 1.25\n\nrequire github.com/go-extras/errx v0.0.0\n\nreplace github.com/go-extras/errx => %s\n",
+	"orders/orders.go": `package orders
+
+import (
+	"errors"
+
+	"github.com/go-extras/errx/stacktrace"
+)
+
+//go:noinline
+func Load() error { return stacktrace.Wrap("failed", errors.New("cause")) }
+
+//go:noinline
+func Save() error { return stacktrace.Wrap("failed", errors.New("cause")) }
+`,
+	"main.go": `package main
+
+import (
+	"fmt"
+
+	"github.com/go-extras/errx"
+	"myservice/orders"
+)
+
+func main() {
+	fmt.Print(errx.Fingerprint(orders.Load()) != errx.Fingerprint(orders.Save()))
+}
+`,
+}
+
+func TestFingerprint_DotlessModulePath(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a module with the go command")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go command not available")
+	}
+	root, err := filepath.Abs(".")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, content := range dotlessModule {
+		if name == "go.mod" {
+			content = strings.Replace(content, "%s", root, 1)
+		}
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, flags := range [][]string{nil, {"-trimpath"}} {
+		cmd := exec.Command(goTool, append(append([]string{"run"}, flags...), ".")...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod", "GOWORK=off", "GOPROXY=off")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("go run %v: %v\n%s", flags, err, out)
+		}
+		if string(out) != "true" {
+			t.Errorf("go run %v: expected the service's own frames to be part of the fingerprint", flags)
+		}
+	}
+}
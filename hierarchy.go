@@ -0,0 +1,202 @@
+package errx
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-extras/errx/internal/errptr"
+)
+
+// maxHierarchyDepth bounds walks up the sentinel hierarchy, so that accidental
+// parent cycles cannot recurse forever.
+const maxHierarchyDepth = 64
+
+// Parents returns the parents a sentinel was declared with, in declaration order.
+// Severity classifications passed to NewSentinel are not parents. Classifications
+// wrapping a sentinel, such as those returned by compat.MarkTimeout, have the parents
+// of the wrapped sentinel, as in MostSpecific.
+// Returns nil if s is not a sentinel or has no parents.
+//
+// Example:
+//
+//	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase, ErrRetryable)
+//	errx.Parents(ErrTimeout) // [ErrDatabase ErrRetryable]
+func Parents(s Classified) []Classified {
+	st, ok := asSentinel(s)
+	if !ok {
+		return nil
+	}
+	var result []Classified
+	for _, parent := range st.parents {
+		if _, isSeverity := parent.(*severity); !isSeverity {
+			result = append(result, parent)
+		}
+	}
+	return result
+}
+
+// Ancestors returns the parents of a sentinel, their parents and so on, without
+// duplicates and in topological order: every sentinel comes before its own ancestors.
+// Returns nil if s is not a sentinel or has no parents.
+//
+// Example:
+//
+//	ErrServer   := errx.NewSentinel("server")
+//	ErrDatabase := errx.NewSentinel("database", ErrServer)
+//	ErrTimeout  := errx.NewSentinel("timeout", ErrDatabase, ErrServer)
+//	errx.Ancestors(ErrTimeout) // [ErrDatabase ErrServer]
+func Ancestors(s Classified) []Classified {
+	var postorder []Classified
+	visited := map[uintptr]bool{errptr.Get(s): true}
+
+	var visit func(cls Classified, depth int)
+	visit = func(cls Classified, depth int) {
+		if depth >= maxHierarchyDepth {
+			return
+		}
+		// Parents are visited last to first, so that the reversed postorder
+		// keeps siblings in declaration order
+		parents := Parents(cls)
+		for i := len(parents) - 1; i >= 0; i-- {
+			parent := parents[i]
+			ptr := errptr.Get(parent)
+			if visited[ptr] {
+				continue
+			}
+			visited[ptr] = true
+			visit(parent, depth+1)
+			postorder = append(postorder, parent)
+		}
+	}
+	visit(s, 0)
+
+	if len(postorder) == 0 {
+		return nil
+	}
+	// Reversed postorder lists each sentinel before its parents
+	result := make([]Classified, len(postorder))
+	for i, cls := range postorder {
+		result[len(postorder)-1-i] = cls
+	}
+	return result
+}
+
+// IsDescendant reports whether sentinel a has b among its ancestors.
+// A sentinel is not its own descendant.
+func IsDescendant(a, b Classified) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	target := errptr.Get(b)
+	for _, ancestor := range Ancestors(a) {
+		if errptr.Get(ancestor) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// HierarchyDOT renders the given sentinels and their ancestors as a Graphviz DOT
+// digraph, with an edge from each sentinel to each of its parents. Nodes are labeled
+// with the sentinel text.
+//
+// Example:
+//
+//	os.WriteFile("errors.dot", []byte(errx.HierarchyDOT(ErrNotFound, ErrTimeout)), 0o644)
+//	// dot -Tsvg errors.dot > errors.svg
+func HierarchyDOT(sentinels ...Classified) string {
+	g := newHierarchyGraph(sentinels)
+
+	var b strings.Builder
+	b.WriteString("digraph errx {\n\trankdir=BT;\n")
+	for i, node := range g.nodes {
+		fmt.Fprintf(&b, "\tn%d [label=\"%s\"];\n", i, dotEscaper.Replace(node.Error()))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "\tn%d -> n%d;\n", e[0], e[1])
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// HierarchyMermaid renders the given sentinels and their ancestors as a Mermaid
+// flowchart, with an edge from each sentinel to each of its parents. Nodes are labeled
+// with the sentinel text. The result can be embedded in Markdown in a mermaid code block.
+func HierarchyMermaid(sentinels ...Classified) string {
+	g := newHierarchyGraph(sentinels)
+
+	var b strings.Builder
+	b.WriteString("graph BT\n")
+	for i, node := range g.nodes {
+		fmt.Fprintf(&b, "\tn%d[\"%s\"]\n", i, mermaidEscaper.Replace(node.Error()))
+	}
+	for _, e := range g.edges {
+		fmt.Fprintf(&b, "\tn%d --> n%d\n", e[0], e[1])
+	}
+	return b.String()
+}
+
+var (
+	dotEscaper     = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	mermaidEscaper = strings.NewReplacer(`"`, "#quot;", "\n", " ")
+)
+
+// hierarchyGraph holds the nodes and child-to-parent edges of a sentinel hierarchy.
+type hierarchyGraph struct {
+	nodes []Classified
+	index map[uintptr]int
+	edges [][2]int
+}
+
+// newHierarchyGraph builds the graph of the given sentinels and their ancestors.
+// Nodes are in the order the sentinels are given, followed by their ancestors.
+func newHierarchyGraph(sentinels []Classified) *hierarchyGraph {
+	g := &hierarchyGraph{index: make(map[uintptr]int)}
+	for _, s := range sentinels {
+		if s != nil {
+			g.node(s)
+		}
+	}
+	// Nodes are appended while iterating, so ancestors are expanded as well
+	for i := 0; i < len(g.nodes); i++ {
+		for _, parent := range Parents(g.nodes[i]) {
+			g.edges = append(g.edges, [2]int{i, g.node(parent)})
+		}
+	}
+	return g
+}
+
+// node returns the index of cls, adding it if needed.
+func (g *hierarchyGraph) node(cls Classified) int {
+	ptr := errptr.Get(cls)
+	if i, ok := g.index[ptr]; ok {
+		return i
+	}
+	g.index[ptr] = len(g.nodes)
+	g.nodes = append(g.nodes, cls)
+	return len(g.nodes) - 1
+}
+
+// hierarchyDepth returns the length of the longest path from cls up through its
+// parent sentinels to a sentinel without parent sentinels. Root sentinels have
+// depth 0, and a sentinel is always deeper than each of its ancestors.
+func hierarchyDepth(cls Classified) int {
+	return hierarchyDepthAt(cls, 0)
+}
+
+func hierarchyDepthAt(cls Classified, depth int) int {
+	s, ok := asSentinel(cls)
+	if !ok || depth >= maxHierarchyDepth {
+		return 0
+	}
+	deepest := 0
+	for _, parent := range s.parents {
+		if !isSentinel(parent) {
+			continue
+		}
+		if d := 1 + hierarchyDepthAt(parent, depth+1); d > deepest {
+			deepest = d
+		}
+	}
+	return deepest
+}
@@ -0,0 +1,170 @@
+package errx_test
+
+import (
+	"io/fs"
+	"log/slog"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+)
+
+var (
+	errHierServer    = errx.NewSentinel("server")
+	errHierRetryable = errx.NewSentinel("retryable")
+	errHierDatabase  = errx.NewSentinel("database", errHierServer, errx.Severity(slog.LevelWarn))
+	errHierTimeout   = errx.NewSentinel("timeout", errHierDatabase, errHierRetryable, errHierServer)
+	errHierDeadlock  = errx.NewSentinel("deadlock", errHierTimeout)
+	errHierMissing   = compat.NewSentinel("missing", fs.ErrNotExist)
+)
+
+func texts(classifications []errx.Classified) []string {
+	result := make([]string, len(classifications))
+	for i, cls := range classifications {
+		result[i] = cls.Error()
+	}
+	return result
+}
+
+func equalTexts(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParents(t *testing.T) {
+	tests := []struct {
+		name string
+		s    errx.Classified
+		want []string
+	}{
+		{"root", errHierServer, nil},
+		{"severity is not a parent", errHierDatabase, []string{"server"}},
+		{"declaration order", errHierTimeout, []string{"database", "retryable", "server"}},
+		{"standard error parent", errHierMissing, []string{"file does not exist"}},
+		{"wrapped sentinel", compat.MarkTimeout(errHierTimeout), []string{"database", "retryable", "server"}},
+		{"displayable", errx.NewDisplayable("Oops"), nil},
+		{"attributes", errx.Attrs("k", "v"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := texts(errx.Parents(tt.s)); !equalTexts(got, tt.want) {
+				t.Errorf("Parents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParents_ReturnsCopy(t *testing.T) {
+	parents := errx.Parents(errHierTimeout)
+	parents[0] = errHierDeadlock
+	if errx.Parents(errHierTimeout)[0] != errHierDatabase {
+		t.Error("modifying the result of Parents() changed the sentinel")
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	tests := []struct {
+		name string
+		s    errx.Classified
+		want []string
+	}{
+		{"root", errHierServer, nil},
+		{"single chain", errHierDatabase, []string{"server"}},
+		{"diamond", errHierTimeout, []string{"database", "retryable", "server"}},
+		{"grandchild", errHierDeadlock, []string{"timeout", "database", "retryable", "server"}},
+		{"wrapped sentinel", compat.MarkTimeout(errHierDatabase), []string{"server"}},
+		{"child of wrapped sentinel", errx.NewSentinel("stuck", compat.MarkTemporary(errHierTimeout)), []string{"timeout", "database", "retryable", "server"}},
+		{"wrapped root", compat.MarkTimeout(errx.NewSentinel("timeout")), nil},
+		{"not a sentinel", errx.Attrs("k", "v"), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errx.Ancestors(tt.s)
+			if !equalTexts(texts(got), tt.want) {
+				t.Errorf("Ancestors() = %v, want %v", texts(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestAncestors_TopologicalOrder(t *testing.T) {
+	ancestors := errx.Ancestors(errHierDeadlock)
+	position := make(map[errx.Classified]int)
+	for i, a := range ancestors {
+		position[a] = i
+	}
+	for _, a := range ancestors {
+		for _, parent := range errx.Parents(a) {
+			if position[parent] <= position[a] {
+				t.Errorf("%v listed before its child %v", parent, a)
+			}
+		}
+	}
+}
+
+func TestIsDescendant(t *testing.T) {
+	tests := []struct {
+		a, b errx.Classified
+		want bool
+	}{
+		{errHierDeadlock, errHierServer, true},
+		{errHierTimeout, errHierRetryable, true},
+		{errHierServer, errHierDeadlock, false},
+		{errHierTimeout, errHierTimeout, false},
+		{errHierDatabase, errHierRetryable, false},
+		{compat.MarkTimeout(errHierDeadlock), errHierServer, true},
+		{errx.NewSentinel("stuck", compat.MarkTimeout(errHierDatabase)), errHierServer, true},
+		{errHierDatabase, nil, false},
+		{nil, errHierDatabase, false},
+	}
+	for _, tt := range tests {
+		if got := errx.IsDescendant(tt.a, tt.b); got != tt.want {
+			t.Errorf("IsDescendant(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestHierarchyDOT(t *testing.T) {
+	quoted := errx.NewSentinel(`say "hi"`, errHierServer)
+	got := errx.HierarchyDOT(errHierTimeout, quoted)
+	want := `digraph errx {
+	rankdir=BT;
+	n0 [label="timeout"];
+	n1 [label="say \"hi\""];
+	n2 [label="database"];
+	n3 [label="retryable"];
+	n4 [label="server"];
+	n0 -> n2;
+	n0 -> n3;
+	n0 -> n4;
+	n1 -> n4;
+	n2 -> n4;
+}
+`
+	if got != want {
+		t.Errorf("HierarchyDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHierarchyMermaid(t *testing.T) {
+	got := errx.HierarchyMermaid(errHierDatabase, errHierServer, errHierDatabase)
+	want := `graph BT
+	n0["database"]
+	n1["server"]
+	n0 --> n1
+`
+	if got != want {
+		t.Errorf("HierarchyMermaid() =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := errx.HierarchyMermaid(); got != "graph BT\n" {
+		t.Errorf("HierarchyMermaid() = %q for no sentinels", got)
+	}
+}
@@ -0,0 +1,82 @@
+// Package errchain provides traversal of error chains for the errx subpackages.
+package errchain
+
+import (
+	"github.com/go-extras/errx/internal/errptr"
+)
+
+// Walk visits err and every error reachable from it through Unwrap() error and
+// Unwrap() []error, depth-first and in order, until visit returns true.
+// Each error instance is visited at most once, so cyclic chains terminate.
+//
+// Example:
+//
+//	var found error
+//	errchain.Walk(err, func(e error) bool {
+//	    if _, ok := e.(interface{ Timeout() bool }); ok {
+//	        found = e
+//	        return true
+//	    }
+//	    return false
+//	})
+func Walk(err error, visit func(error) bool) {
+	visited := make(map[uintptr]bool)
+	stack := []error{err}
+
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if current == nil {
+			continue
+		}
+		ptr := errptr.Get(current)
+		if visited[ptr] {
+			continue
+		}
+		visited[ptr] = true
+
+		if visit(current) {
+			return
+		}
+
+		switch u := current.(type) {
+		case interface{ Unwrap() []error }:
+			errs := u.Unwrap()
+			for i := len(errs) - 1; i >= 0; i-- {
+				stack = append(stack, errs[i])
+			}
+		case interface{ Unwrap() error }:
+			stack = append(stack, u.Unwrap())
+		}
+	}
+}
+
+// Transparent marks a wrapper whose Error() is the message of the error it wraps
+// and which only carries metadata, such as a propagation step of the stacktrace
+// package. Embed it in the wrapper struct; serializers then look through the
+// wrapper as if it were not in the chain.
+type Transparent struct{}
+
+func (Transparent) transparent() {}
+
+// transparent is implemented only by types that embed Transparent.
+type transparent interface {
+	transparent()
+}
+
+// SkipTransparent returns the first error in err's chain that does not embed
+// Transparent, following Unwrap() error. It returns err itself if err is not
+// transparent.
+func SkipTransparent(err error) error {
+	for {
+		if _, ok := err.(transparent); !ok {
+			return err
+		}
+		u, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return err
+		}
+		err = u.Unwrap()
+	}
+}
@@ -0,0 +1,96 @@
+package errchain_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-extras/errx/internal/errchain"
+)
+
+// cyclicError unwraps to itself through next
+type cyclicError struct {
+	next error
+}
+
+func (*cyclicError) Error() string   { return "cyclic" }
+func (e *cyclicError) Unwrap() error { return e.next }
+
+func collect(err error) []string {
+	var messages []string
+	errchain.Walk(err, func(e error) bool {
+		messages = append(messages, e.Error())
+		return false
+	})
+	return messages
+}
+
+func TestWalk_Nil(t *testing.T) {
+	if messages := collect(nil); len(messages) != 0 {
+		t.Errorf("expected no visits, got %v", messages)
+	}
+}
+
+func TestWalk_Order(t *testing.T) {
+	a := errors.New("a")
+	b := errors.New("b")
+	c := errors.New("c")
+	err := fmt.Errorf("outer: %w", errors.Join(fmt.Errorf("left: %w", a), b, c))
+
+	got := collect(err)
+	want := []string{err.Error(), "left: a\nb\nc", "left: a", "a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d visits, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("visit %d: expected %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestWalk_Stop(t *testing.T) {
+	err := fmt.Errorf("outer: %w", fmt.Errorf("middle: %w", errors.New("inner")))
+
+	visits := 0
+	errchain.Walk(err, func(error) bool {
+		visits++
+		return visits == 2
+	})
+	if visits != 2 {
+		t.Errorf("expected walk to stop after 2 visits, got %d", visits)
+	}
+}
+
+func TestWalk_Cycle(t *testing.T) {
+	e := &cyclicError{}
+	e.next = e
+
+	if messages := collect(e); len(messages) != 1 {
+		t.Errorf("expected a single visit, got %d", len(messages))
+	}
+}
+
+// transparentError embeds errchain.Transparent
+type transparentError struct {
+	errchain.Transparent
+	err error
+}
+
+func (e *transparentError) Error() string { return e.err.Error() }
+func (e *transparentError) Unwrap() error { return e.err }
+
+func TestSkipTransparent(t *testing.T) {
+	inner := fmt.Errorf("wrapped: %w", errors.New("base"))
+	err := &transparentError{err: &transparentError{err: inner}}
+
+	if got := errchain.SkipTransparent(err); got != inner {
+		t.Errorf("expected the first non-transparent error, got %v", got)
+	}
+	if got := errchain.SkipTransparent(inner); got != inner {
+		t.Errorf("expected a non-transparent error to be returned as is, got %v", got)
+	}
+	if got := errchain.SkipTransparent(nil); got != nil {
+		t.Errorf("expected nil, got %v", got)
+	}
+}
@@ -0,0 +1,56 @@
+// Package foreignstack detects the stack traces exposed by errors of other error
+// libraries, structurally and without importing those libraries.
+package foreignstack
+
+import "reflect"
+
+// StackTrace returns a function calling the StackTrace method of err and converting
+// its result to program counters, if err has a StackTrace method without arguments
+// returning a slice of an uintptr-based type. This is the method implemented by
+// github.com/pkg/errors, whose errors.StackTrace is a []errors.Frame, and Frame
+// is a program counter.
+//
+// Example:
+//
+//	if stackTrace, ok := foreignstack.StackTrace(err); ok {
+//	    pcs := stackTrace()
+//	}
+func StackTrace(err error) (func() []uintptr, bool) {
+	if err == nil {
+		return nil, false
+	}
+	method := reflect.ValueOf(err).MethodByName("StackTrace")
+	if !method.IsValid() {
+		return nil, false
+	}
+	mt := method.Type()
+	if mt.NumIn() != 0 || mt.NumOut() != 1 {
+		return nil, false
+	}
+	out := mt.Out(0)
+	if out.Kind() != reflect.Slice || out.Elem().Kind() != reflect.Uintptr {
+		return nil, false
+	}
+
+	return func() []uintptr {
+		stack := method.Call(nil)[0]
+		pcs := make([]uintptr, stack.Len())
+		for i := range pcs {
+			pcs[i] = uintptr(stack.Index(i).Uint())
+		}
+		return pcs
+	}, true
+}
+
+// PCs returns the program counters exposed by err itself, through Callers() []uintptr
+// (as implemented by github.com/go-errors/errors) or StackTrace (see StackTrace).
+// It returns nil if err exposes no stack. The rest of err's chain is not inspected.
+func PCs(err error) []uintptr {
+	if c, ok := err.(interface{ Callers() []uintptr }); ok {
+		return c.Callers()
+	}
+	if stackTrace, ok := StackTrace(err); ok {
+		return stackTrace()
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package foreignstack_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/go-extras/errx/internal/foreignstack"
+)
+
+// frame mimics github.com/pkg/errors.Frame
+type frame uintptr
+
+// stackTraceError mimics the errors of github.com/pkg/errors
+type stackTraceError struct{}
+
+func (stackTraceError) Error() string       { return "pkg/errors" }
+func (stackTraceError) StackTrace() []frame { return []frame{1, 2, 3} }
+
+// callersError mimics the errors of github.com/go-errors/errors
+type callersError struct{}
+
+func (callersError) Error() string      { return "go-errors" }
+func (callersError) Callers() []uintptr { return []uintptr{4, 5} }
+
+// stringStackError has a StackTrace method of an unrelated shape
+type stringStackError struct{}
+
+func (stringStackError) Error() string        { return "strings" }
+func (stringStackError) StackTrace() []string { return []string{"main.go:1"} }
+
+func TestStackTrace(t *testing.T) {
+	stackTrace, ok := foreignstack.StackTrace(stackTraceError{})
+	if !ok {
+		t.Fatal("expected StackTrace to be detected")
+	}
+	if pcs := stackTrace(); !slices.Equal(pcs, []uintptr{1, 2, 3}) {
+		t.Errorf("StackTrace() = %v, want [1 2 3]", pcs)
+	}
+
+	for _, err := range []error{stringStackError{}, callersError{}, errors.New("plain"), nil} {
+		if _, ok := foreignstack.StackTrace(err); ok {
+			t.Errorf("expected no StackTrace for %T", err)
+		}
+	}
+}
+
+func TestPCs(t *testing.T) {
+	tests := []struct {
+		err  error
+		want []uintptr
+	}{
+		{stackTraceError{}, []uintptr{1, 2, 3}},
+		{callersError{}, []uintptr{4, 5}},
+		{stringStackError{}, nil},
+		{errors.New("plain"), nil},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := foreignstack.PCs(tt.err); !slices.Equal(got, tt.want) {
+			t.Errorf("PCs(%T) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,267 @@
+// Package srcload loads and type-checks Go packages from source for the errx
+// commands, using only the standard library.
+package srcload
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Package is a parsed and type-checked package.
+//
+// Type checking is best-effort: if dependencies cannot be loaded, Types and Info
+// are incomplete and TypeErrors lists what went wrong. Callers should fall back to
+// syntactic analysis where type information is missing.
+type Package struct {
+	Path       string // Import path, derived from the enclosing go.mod
+	Name       string
+	Dir        string
+	Fset       *token.FileSet
+	Files      []*ast.File
+	Types      *types.Package
+	Info       *types.Info
+	TypeErrors []error
+}
+
+// Load loads the packages matching the given patterns. A pattern is a directory,
+// or a directory followed by "/..." to include all directories below it, skipping
+// testdata, vendor and directories starting with "." or "_".
+// Test files and files excluded by build constraints (see go/build.Default) are not
+// loaded. Directories without Go files are skipped.
+func Load(patterns ...string) ([]*Package, error) {
+	dirs, err := expand(patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+
+	var pkgs []*Package
+	for _, dir := range dirs {
+		pkg, err := loadDir(fset, imp, dir)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+// expand resolves patterns to a sorted list of unique directories.
+func expand(patterns []string) ([]string, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	seen := make(map[string]bool)
+	var dirs []string
+	add := func(dir string) {
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "/...")
+		if root == "" {
+			root = "."
+		}
+		root = filepath.FromSlash(root)
+
+		info, err := os.Stat(root)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("%s is not a directory", pattern)
+		}
+		if !recursive {
+			add(filepath.Clean(root))
+			continue
+		}
+
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				return nil
+			}
+			name := d.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			add(filepath.Clean(path))
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// loadDir parses and type-checks the non-test Go files in dir that match the
+// default build context, as go build would select them.
+// It returns nil if dir contains no such files.
+func loadDir(fset *token.FileSet, imp types.Importer, dir string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		match, err := build.Default.MatchFile(dir, name)
+		if err != nil {
+			return nil, err
+		}
+		if !match {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	pkg := &Package{
+		Path:  importPath(dir),
+		Name:  files[0].Name.Name,
+		Dir:   dir,
+		Fset:  fset,
+		Files: files,
+		Info: &types.Info{
+			Types: make(map[ast.Expr]types.TypeAndValue),
+			Defs:  make(map[*ast.Ident]types.Object),
+			Uses:  make(map[*ast.Ident]types.Object),
+		},
+	}
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			pkg.TypeErrors = append(pkg.TypeErrors, err)
+		},
+	}
+	pkg.Types, _ = conf.Check(pkg.Path, fset, files, pkg.Info)
+	return pkg, nil
+}
+
+// importPath derives the import path of dir from the nearest go.mod.
+// It falls back to the directory name if there is no go.mod.
+func importPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.ToSlash(dir)
+	}
+	for root := abs; ; {
+		if module := modulePath(filepath.Join(root, "go.mod")); module != "" {
+			rel, err := filepath.Rel(root, abs)
+			if err != nil || rel == "." {
+				return module
+			}
+			return module + "/" + filepath.ToSlash(rel)
+		}
+		parent := filepath.Dir(root)
+		if parent == root {
+			return filepath.Base(abs)
+		}
+		root = parent
+	}
+}
+
+// modulePath returns the module path declared in a go.mod file, or "".
+func modulePath(gomod string) string {
+	data, err := os.ReadFile(gomod) //nolint:gosec // reading go.mod files is the purpose
+	if err != nil {
+		return ""
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if module, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "module "); ok {
+			return strings.Trim(strings.TrimSpace(module), `"`)
+		}
+	}
+	return ""
+}
+
+// Callee returns the package path and name of the function called by call, such
+// as ("github.com/go-extras/errx", "Wrap"). It uses type information when it is
+// available, and otherwise resolves the package from the file's imports.
+// It returns empty strings for calls that are not to package-level functions.
+func (p *Package) Callee(file *ast.File, call *ast.CallExpr) (pkgPath, name string) {
+	var fun ast.Expr = call.Fun
+	if index, ok := fun.(*ast.IndexExpr); ok { // Generic instantiation
+		fun = index.X
+	}
+	sel, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		if ident, ok := fun.(*ast.Ident); ok {
+			if fn, ok := p.Info.Uses[ident].(*types.Func); ok && fn.Pkg() != nil {
+				return fn.Pkg().Path(), fn.Name()
+			}
+		}
+		return "", ""
+	}
+
+	if fn, ok := p.Info.Uses[sel.Sel].(*types.Func); ok {
+		if fn.Pkg() == nil || fn.Signature().Recv() != nil {
+			return "", ""
+		}
+		return fn.Pkg().Path(), fn.Name()
+	}
+
+	x, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return "", ""
+	}
+	if pkgName, ok := p.Info.Uses[x].(*types.PkgName); ok {
+		return pkgName.Imported().Path(), sel.Sel.Name
+	}
+	if path := ImportedAs(file, x.Name); path != "" {
+		return path, sel.Sel.Name
+	}
+	return "", ""
+}
+
+// ImportedAs returns the path of the package imported under name in file, or "".
+// Packages imported without a name are matched by the last element of their path.
+func ImportedAs(file *ast.File, name string) string {
+	for _, spec := range file.Imports {
+		path := strings.Trim(spec.Path.Value, `"`)
+		local := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			local = spec.Name.Name
+		}
+		if local == name {
+			return path
+		}
+	}
+	return ""
+}
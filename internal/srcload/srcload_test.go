@@ -0,0 +1,133 @@
+package srcload_test
+
+import (
+	"go/ast"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-extras/errx/internal/srcload"
+)
+
+// writeFile creates a file with its parent directories
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoad_Patterns(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(root, "a", "a.go"), "package a\n")
+	writeFile(t, filepath.Join(root, "a", "a_test.go"), "package a\n\nfunc broken( {\n")
+	writeFile(t, filepath.Join(root, "a", "b", "b.go"), "package b\n")
+	writeFile(t, filepath.Join(root, "a", "testdata", "t.go"), "package t\n")
+	writeFile(t, filepath.Join(root, "a", "_skip", "s.go"), "package s\n")
+
+	pkgs, err := srcload.Load(filepath.Join(root, "a") + "/...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 2 {
+		t.Fatalf("expected 2 packages, got %d", len(pkgs))
+	}
+	if pkgs[0].Path != "example.com/app/a" || pkgs[1].Path != "example.com/app/a/b" {
+		t.Errorf("unexpected import paths %q, %q", pkgs[0].Path, pkgs[1].Path)
+	}
+	if pkgs[1].Name != "b" {
+		t.Errorf("unexpected package name %q", pkgs[1].Name)
+	}
+
+	pkgs, err = srcload.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 0 {
+		t.Errorf("expected directory without Go files to be skipped, got %d packages", len(pkgs))
+	}
+
+	if _, err := srcload.Load(filepath.Join(root, "missing")); err == nil {
+		t.Error("expected error for missing directory")
+	}
+}
+
+func TestCallee(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/app\n")
+	writeFile(t, filepath.Join(root, "main.go"), `package app
+
+import (
+	"strings"
+
+	m "example.com/missing"
+)
+
+func helper() {}
+
+func run() {
+	_ = strings.ToUpper("x")
+	m.Do()
+	helper()
+	var b strings.Builder
+	b.Reset()
+}
+`)
+
+	pkgs, err := srcload.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg := pkgs[0]
+	if len(pkg.TypeErrors) == 0 {
+		t.Error("expected type errors for the missing import")
+	}
+
+	var got []string
+	ast.Inspect(pkg.Files[0], func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			path, name := pkg.Callee(pkg.Files[0], call)
+			got = append(got, path+"."+name)
+		}
+		return true
+	})
+
+	want := []string{"strings.ToUpper", "example.com/missing.Do", "example.com/app.helper", "."}
+	if len(got) != len(want) {
+		t.Fatalf("Callee() results = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Callee() = %q, want %q", got[i], want[i])
+		}
+	}
+}
+
+func TestLoad_BuildConstraints(t *testing.T) {
+	pkgs, err := srcload.Load(filepath.Join("testdata", "constrained"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pkgs) != 1 {
+		t.Fatalf("expected 1 package, got %d", len(pkgs))
+	}
+	pkg := pkgs[0]
+	if pkg.Name != "constrained" {
+		t.Errorf("unexpected package name %q", pkg.Name)
+	}
+	if len(pkg.TypeErrors) != 0 {
+		t.Errorf("expected excluded files not to be type-checked, got %v", pkg.TypeErrors)
+	}
+
+	var names []string
+	for _, file := range pkg.Files {
+		names = append(names, filepath.Base(pkg.Fset.Position(file.Package).Filename))
+	}
+	if len(names) != 2 || names[0] != "constrained.go" || names[1] != "mode_default.go" {
+		t.Errorf("loaded files = %v, want [constrained.go mode_default.go]", names)
+	}
+}
@@ -0,0 +1,6 @@
+// Package constrained has files excluded by build constraints, which declare
+// conflicting identifiers and a different package name.
+package constrained
+
+// Mode reports which variant of mode was built.
+func Mode() string { return mode }
@@ -0,0 +1,5 @@
+//go:build !srcload_alt
+
+package constrained
+
+const mode = "default"
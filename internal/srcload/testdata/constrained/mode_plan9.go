@@ -0,0 +1,3 @@
+package constrained
+
+const mode = "plan9"
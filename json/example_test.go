@@ -1,12 +1,15 @@
 package json_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 
 	"github.com/go-extras/errx"
 	errxjson "github.com/go-extras/errx/json"
-	"github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/errx/tracectx"
 )
 
 var (
@@ -78,20 +81,6 @@ func ExampleWithMaxDepth() {
 	// {"message":"level 1: level 2: level 3","cause":{"message":"level 2: level 3","cause":{"message":"(max depth reached)"}}}
 }
 
-// ExampleWithMaxStackFrames demonstrates limiting stack trace frames
-func ExampleWithMaxStackFrames() {
-	err := stacktrace.Wrap("operation failed", errors.New("base error"))
-
-	// Limit to 3 stack frames
-	serialized := errxjson.ToSerializedError(err, errxjson.WithMaxStackFrames(3))
-	fmt.Printf("Stack frames: %d\n", len(serialized.StackTrace))
-	fmt.Println("Has stack trace: true")
-
-	// Output:
-	// Stack frames: 3
-	// Has stack trace: true
-}
-
 // ExampleWithIncludeStandardErrors demonstrates filtering error types
 func ExampleWithIncludeStandardErrors() {
 	// Mix of errx and standard errors
@@ -167,30 +156,6 @@ func Example_attributedError() {
 	// }
 }
 
-// Example_complexError demonstrates serializing errors with all features
-func Example_complexError() {
-	// Build a rich error with all features
-	baseErr := errors.New("connection timeout")
-	displayErr := errx.NewDisplayable("Service temporarily unavailable")
-	attrErr := errx.Attrs("retry_count", 3, "host", "localhost")
-
-	err := stacktrace.Wrap("database query failed",
-		baseErr, displayErr, attrErr, ErrDatabase)
-
-	serialized := errxjson.ToSerializedError(err)
-
-	fmt.Printf("Has display text: %v\n", serialized.DisplayText != "")
-	fmt.Printf("Has attributes: %v\n", len(serialized.Attributes) > 0)
-	fmt.Printf("Has stack trace: %v\n", len(serialized.StackTrace) > 0)
-	fmt.Printf("Has sentinels: %v\n", len(serialized.Sentinels) > 0)
-
-	// Output:
-	// Has display text: true
-	// Has attributes: true
-	// Has stack trace: true
-	// Has sentinels: true
-}
-
 // Example_errorChain demonstrates serializing error chains
 func Example_errorChain() {
 	err1 := errors.New("root cause")
@@ -244,3 +209,22 @@ func Example_apiResponse() {
 	//   }
 	// }
 }
+
+// ExampleNewProblem demonstrates writing an RFC 9457 problem+json response
+func ExampleNewProblem() {
+	ctx := tracectx.ContextWithSpan(context.Background(), tracectx.SpanContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	})
+	cause := errx.Classify(errors.New("sql: no rows"), errx.NewDisplayable("Order not found"))
+	err := tracectx.WrapCtx(ctx, "failed to load order", cause, ErrNotFound)
+
+	rec := httptest.NewRecorder()
+	_ = errxjson.WriteProblem(rec, errxjson.NewProblem(err, http.StatusNotFound))
+	fmt.Println(rec.Header().Get("Content-Type"))
+	fmt.Println(rec.Body.String())
+
+	// Output:
+	// application/problem+json
+	// {"title":"Not Found","status":404,"detail":"Order not found","trace_id":"4bf92f3577b34da6a3ce929d0e0e4736","span_id":"00f067aa0ba902b7"}
+}
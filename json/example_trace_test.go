@@ -0,0 +1,50 @@
+//go:build !errx_notrace
+
+package json_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// ExampleWithMaxStackFrames demonstrates limiting stack trace frames
+func ExampleWithMaxStackFrames() {
+	err := stacktrace.Wrap("operation failed", errors.New("base error"))
+
+	// Limit to 3 stack frames
+	serialized := errxjson.ToSerializedError(err, errxjson.WithMaxStackFrames(3))
+	fmt.Printf("Stack frames: %d\n", len(serialized.StackTrace))
+	fmt.Println("Has stack trace: true")
+
+	// Output:
+	// Stack frames: 3
+	// Has stack trace: true
+}
+
+// Example_complexError demonstrates serializing errors with all features
+func Example_complexError() {
+	// Build a rich error with all features
+	baseErr := errors.New("connection timeout")
+	displayErr := errx.NewDisplayable("Service temporarily unavailable")
+	attrErr := errx.Attrs("retry_count", 3, "host", "localhost")
+
+	err := stacktrace.Wrap("database query failed",
+		baseErr, displayErr, attrErr, ErrDatabase)
+
+	serialized := errxjson.ToSerializedError(err)
+
+	fmt.Printf("Has display text: %v\n", serialized.DisplayText != "")
+	fmt.Printf("Has attributes: %v\n", len(serialized.Attributes) > 0)
+	fmt.Printf("Has stack trace: %v\n", len(serialized.StackTrace) > 0)
+	fmt.Printf("Has sentinels: %v\n", len(serialized.Sentinels) > 0)
+
+	// Output:
+	// Has display text: true
+	// Has attributes: true
+	// Has stack trace: true
+	// Has sentinels: true
+}
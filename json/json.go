@@ -23,11 +23,15 @@ package json
 import (
 	"encoding/json"
 	"errors"
+	"io/fs"
+	"log/slog"
 	"reflect"
 
 	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/internal/errchain"
 	"github.com/go-extras/errx/internal/errptr"
 	"github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/errx/tracectx"
 )
 
 // SerializedError represents the JSON structure of an errx error.
@@ -46,14 +50,27 @@ type SerializedError struct {
 	// Attributes contains structured key-value pairs attached to this error
 	Attributes []SerializedAttr `json:"attributes,omitempty"`
 
+	// TraceID and SpanID contain the distributed tracing identifiers attached
+	// with the tracectx package, if any
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+
 	// StackTrace contains stack frames if a stack trace was captured
 	StackTrace []SerializedFrame `json:"stack_trace,omitempty"`
 
+	// GoroutineAncestors contains the spawn stacks of the goroutines the stack trace
+	// was captured in (nearest first), if they were started with stacktrace.Go
+	GoroutineAncestors [][]SerializedFrame `json:"goroutine_ancestors,omitempty"`
+
 	// Cause is the wrapped error (single unwrap)
 	Cause *SerializedError `json:"cause,omitempty"`
 
 	// Causes contains multiple wrapped errors (multi-error unwrap)
 	Causes []*SerializedError `json:"causes,omitempty"`
+
+	// Err is the error this node was serialized from. It is not serialized, and is
+	// nil for nodes decoded from JSON and for depth and circular reference markers.
+	Err error `json:"-"`
 }
 
 // SerializedAttr represents a single attribute key-value pair.
@@ -67,6 +84,17 @@ type SerializedFrame struct {
 	File     string `json:"file"`
 	Line     int    `json:"line"`
 	Function string `json:"function"`
+
+	// Context contains the source lines surrounding the frame's line,
+	// if enabled with WithSourceContext and the source is available
+	Context []SerializedSourceLine `json:"context,omitempty"`
+}
+
+// SerializedSourceLine represents a single line of source code around a stack frame.
+type SerializedSourceLine struct {
+	Line    int    `json:"line"`
+	Text    string `json:"text"`
+	Current bool   `json:"current,omitempty"`
 }
 
 // config holds serialization configuration.
@@ -74,6 +102,10 @@ type config struct {
 	maxDepth              int
 	maxStackFrames        int
 	includeStandardErrors bool
+	sourceLines           int
+	sourceFS              fs.FS
+	followCause           bool
+	sources               *stacktrace.SourceReader // Created on first use when sourceLines > 0
 }
 
 // defaultConfig returns the default configuration.
@@ -153,6 +185,8 @@ func ToSerializedError(err error, opts ...Option) *SerializedError {
 
 // toSerializedError recursively converts an error to SerializedError.
 func toSerializedError(err error, cfg *config, visited map[uintptr]bool, depth int) *SerializedError {
+	// Propagation steps share the message of the error they wrap, so serialize that error instead
+	err = errchain.SkipTransparent(err)
 	if err == nil {
 		return nil
 	}
@@ -177,6 +211,7 @@ func toSerializedError(err error, cfg *config, visited map[uintptr]bool, depth i
 
 	result := &SerializedError{
 		Message: err.Error(),
+		Err:     err,
 	}
 
 	// Extract displayable text
@@ -212,26 +247,67 @@ func serializeAttributes(err error, result *SerializedError) {
 			Value: attr.Value,
 		}
 	}
+	result.TraceID, result.SpanID = tracectx.IDs(err)
 }
 
-// serializeStackTrace extracts and serializes stack frames from an error.
+// serializeStackTrace extracts and serializes stack frames and goroutine ancestry from an error.
 func serializeStackTrace(err error, cfg *config, result *SerializedError) {
 	frames := stacktrace.Extract(err)
 	if len(frames) == 0 {
 		return
 	}
+	result.StackTrace = serializeFrames(frames, cfg)
+
+	ancestors := stacktrace.ExtractAncestors(err)
+	if len(ancestors) == 0 {
+		return
+	}
+	result.GoroutineAncestors = make([][]SerializedFrame, len(ancestors))
+	for i, ancestor := range ancestors {
+		result.GoroutineAncestors[i] = serializeFrames(ancestor, cfg)
+	}
+}
+
+// serializeFrames converts stack frames, honoring the configured frame limit.
+func serializeFrames(frames []stacktrace.Frame, cfg *config) []SerializedFrame {
 	limit := len(frames)
 	if cfg.maxStackFrames > 0 && limit > cfg.maxStackFrames {
 		limit = cfg.maxStackFrames
 	}
-	result.StackTrace = make([]SerializedFrame, limit)
+	result := make([]SerializedFrame, limit)
 	for i := 0; i < limit; i++ {
-		result.StackTrace[i] = SerializedFrame{
+		result[i] = SerializedFrame{
 			File:     frames[i].File,
 			Line:     frames[i].Line,
 			Function: frames[i].Function,
+			Context:  serializeSourceContext(frames[i], cfg),
+		}
+	}
+	return result
+}
+
+// serializeSourceContext reads the source lines around a frame, if enabled.
+func serializeSourceContext(frame stacktrace.Frame, cfg *config) []SerializedSourceLine {
+	if cfg.sourceLines <= 0 {
+		return nil
+	}
+	if cfg.sources == nil {
+		cfg.sources = stacktrace.NewSourceReader(cfg.sourceFS)
+	}
+
+	lines := cfg.sources.Context(frame, cfg.sourceLines)
+	if len(lines) == 0 {
+		return nil
+	}
+	result := make([]SerializedSourceLine, len(lines))
+	for i, line := range lines {
+		result[i] = SerializedSourceLine{
+			Line:    line.Line,
+			Text:    line.Text,
+			Current: line.Current,
 		}
 	}
+	return result
 }
 
 // serializeCauses handles unwrapping and serialization of error causes.
@@ -275,6 +351,10 @@ type unwrapper interface {
 // serializeSingleCause serializes a single error cause.
 func serializeSingleCause(err error, cfg *config, visited map[uintptr]bool, depth int, result *SerializedError) {
 	cause := errors.Unwrap(err)
+	if cause == nil && cfg.followCause {
+		cause = legacyCause(err)
+	}
+	cause = errchain.SkipTransparent(cause)
 	if cause == nil {
 		return
 	}
@@ -286,7 +366,7 @@ func serializeSingleCause(err error, cfg *config, visited map[uintptr]bool, dept
 			ptr := errptr.Get(cause)
 			visited[ptr] = true
 		}
-		innerCause := errors.Unwrap(cause)
+		innerCause := errchain.SkipTransparent(errors.Unwrap(cause))
 		if innerCause != nil && (cfg.includeStandardErrors || isErrxError(innerCause)) {
 			result.Cause = toSerializedError(innerCause, cfg, visited, depth+1)
 		}
@@ -298,6 +378,16 @@ func serializeSingleCause(err error, cfg *config, visited map[uintptr]bool, dept
 	}
 }
 
+// legacyCause returns the cause of an error implementing the legacy Cause() error
+// method without Unwrap, or nil.
+func legacyCause(err error) error {
+	c, ok := err.(interface{ Cause() error })
+	if !ok {
+		return nil
+	}
+	return c.Cause()
+}
+
 // extractSentinelsFromError extracts sentinel texts from the error and its immediate cause if it's a carrier.
 func extractSentinelsFromError(err error) []string {
 	if err == nil {
@@ -336,14 +426,20 @@ func addPureSentinels(classifications []errx.Classified, sentinels *[]string, se
 
 // isPureSentinel checks if a classified error is a pure sentinel.
 func isPureSentinel(cls errx.Classified) bool {
-	return !errx.IsDisplayable(cls) && !errx.HasAttrs(cls) && stacktrace.Extract(cls) == nil
+	return !errx.IsDisplayable(cls) && !errx.HasAttrs(cls) && !stacktrace.HasTrace(cls) && !isSeverity(cls)
+}
+
+// isSeverity checks if a classified error was created by errx.Severity.
+func isSeverity(cls errx.Classified) bool {
+	_, ok := cls.(interface{ Level() slog.Level })
+	return ok
 }
 
 // extractFromCarrierCauses extracts sentinels from carrier causes up to 2 levels deep.
 func extractFromCarrierCauses(err error, sentinels *[]string, seen map[string]bool) {
 	current := err
 	for i := 0; i < 2; i++ {
-		cause := errors.Unwrap(current)
+		cause := errchain.SkipTransparent(errors.Unwrap(current))
 		if cause == nil || !isCarrier(cause) {
 			break
 		}
@@ -416,12 +512,13 @@ func extractCarrierClassifications(err error) []errx.Classified {
 	return result
 }
 
-// isErrxError checks if an error is an errx error (implements Classified).
+// isErrxError checks if an error is an errx error (implements Classified),
+// looking through propagation steps.
 func isErrxError(err error) bool {
 	if err == nil {
 		return false
 	}
-	_, ok := err.(errx.Classified)
+	_, ok := errchain.SkipTransparent(err).(errx.Classified)
 	return ok
 }
 
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/go-extras/errx"
@@ -126,38 +129,6 @@ func TestMarshal_AttributedError(t *testing.T) {
 	}
 }
 
-func TestMarshal_StackTrace(t *testing.T) {
-	testErr := stacktrace.Wrap("operation failed", errors.New("base error"), ErrDatabaseTest)
-
-	data, err := errxjson.Marshal(testErr)
-	if err != nil {
-		t.Fatalf("Marshal error: %v", err)
-	}
-
-	var result errxjson.SerializedError
-	if err := json.Unmarshal(data, &result); err != nil {
-		t.Fatalf("Unmarshal error: %v", err)
-	}
-
-	if len(result.StackTrace) == 0 {
-		t.Error("StackTrace is empty, want non-empty")
-	}
-
-	// Check first frame has required fields
-	if len(result.StackTrace) > 0 {
-		frame := result.StackTrace[0]
-		if frame.File == "" {
-			t.Error("Frame.File is empty")
-		}
-		if frame.Line == 0 {
-			t.Error("Frame.Line is 0")
-		}
-		if frame.Function == "" {
-			t.Error("Frame.Function is empty")
-		}
-	}
-}
-
 func TestMarshal_ErrorChain(t *testing.T) {
 	err1 := errors.New("level 3")
 	err2 := errx.Wrap("level 2", err1, ErrDatabaseTest)
@@ -209,40 +180,6 @@ func TestMarshal_HierarchicalSentinels(t *testing.T) {
 	}
 }
 
-func TestMarshal_ComplexError(t *testing.T) {
-	// Create a complex error with all features
-	baseErr := errors.New("connection failed")
-	displayErr := errx.NewDisplayable("Service temporarily unavailable")
-	attrErr := errx.Attrs("retry_count", 3, "host", "localhost")
-
-	testErr := stacktrace.Wrap("database operation failed",
-		errx.Classify(baseErr, displayErr, attrErr, ErrTimeoutTest))
-
-	data, err := errxjson.Marshal(testErr)
-	if err != nil {
-		t.Fatalf("Marshal error: %v", err)
-	}
-
-	var result errxjson.SerializedError
-	if err := json.Unmarshal(data, &result); err != nil {
-		t.Fatalf("Unmarshal error: %v", err)
-	}
-
-	// Verify all components are present
-	if result.DisplayText != "Service temporarily unavailable" {
-		t.Errorf("DisplayText = %q, want %q", result.DisplayText, "Service temporarily unavailable")
-	}
-	if len(result.Attributes) != 2 {
-		t.Errorf("len(Attributes) = %d, want 2", len(result.Attributes))
-	}
-	if len(result.StackTrace) == 0 {
-		t.Error("StackTrace is empty")
-	}
-	if len(result.Sentinels) == 0 {
-		t.Error("Sentinels is empty")
-	}
-}
-
 func TestMarshalIndent(t *testing.T) {
 	testErr := errx.Wrap("failed", errors.New("base"), ErrNotFoundTest)
 
@@ -326,6 +263,43 @@ func TestWithMaxStackFrames(t *testing.T) {
 	}
 }
 
+// callersError mimics an error from github.com/go-errors/errors
+type callersError struct {
+	stack []uintptr
+}
+
+func (*callersError) Error() string        { return "foreign failure" }
+func (e *callersError) Callers() []uintptr { return e.stack }
+
+func TestMarshal_ForeignStackTrace(t *testing.T) {
+	pcs := make([]uintptr, 32)
+	foreign := &callersError{stack: pcs[:runtime.Callers(1, pcs)]}
+	testErr := errx.Wrap("operation failed", foreign, ErrDatabaseTest)
+
+	result := errxjson.ToSerializedError(testErr)
+	if len(result.StackTrace) == 0 {
+		t.Fatal("StackTrace is empty, want foreign frames")
+	}
+	if result.StackTrace[0].Function != "github.com/go-extras/errx/json_test.TestMarshal_ForeignStackTrace" {
+		t.Errorf("StackTrace[0].Function = %q, want test function", result.StackTrace[0].Function)
+	}
+	if len(result.Sentinels) != 1 || result.Sentinels[0] != "database" {
+		t.Errorf("Sentinels = %v, want [database]", result.Sentinels)
+	}
+}
+
+func TestWithSourceContext_Disabled(t *testing.T) {
+	testErr := stacktrace.Wrap("operation failed", errors.New("base error"))
+
+	data, err := errxjson.Marshal(testErr)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if strings.Contains(string(data), `"context"`) {
+		t.Errorf("Marshal output contains context without WithSourceContext: %s", data)
+	}
+}
+
 func TestWithIncludeStandardErrors_False(t *testing.T) {
 	// Mix of errx and standard errors
 	stdErr := errors.New("standard error")
@@ -483,50 +457,6 @@ func (e *unhashableError) Error() string {
 	return e.message
 }
 
-func TestMarshal_UnhashableError(t *testing.T) {
-	// Create an unhashable error (contains a map field)
-	unhashableErr := &unhashableError{
-		message: "validation failed",
-		data: map[string]any{
-			"email": "required",
-			"age":   "must be 18 or older",
-		},
-	}
-
-	// Wrap it with errx - this should not panic
-	wrappedErr := stacktrace.Wrap("operation failed", unhashableErr)
-
-	// Marshal should handle unhashable errors gracefully
-	data, err := errxjson.Marshal(wrappedErr)
-	if err != nil {
-		t.Fatalf("Marshal error: %v", err)
-	}
-
-	var result errxjson.SerializedError
-	if err := json.Unmarshal(data, &result); err != nil {
-		t.Fatalf("Unmarshal error: %v", err)
-	}
-
-	// Verify the error was serialized correctly
-	if result.Message != "operation failed: validation failed" {
-		t.Errorf("Message = %q, want %q", result.Message, "operation failed: validation failed")
-	}
-
-	// Verify the cause was included
-	if result.Cause == nil {
-		t.Fatal("Cause should not be nil")
-	}
-
-	if result.Cause.Message != "validation failed" {
-		t.Errorf("Cause.Message = %q, want %q", result.Cause.Message, "validation failed")
-	}
-
-	// Verify stack trace was captured
-	if len(result.StackTrace) == 0 {
-		t.Error("StackTrace should not be empty")
-	}
-}
-
 func TestMarshal_UnhashableErrorChain(t *testing.T) {
 	// Create a chain of unhashable errors to verify they can be marshaled
 	// without panic, even though they can't be used as map keys
@@ -866,3 +796,77 @@ func TestMarshal_ValueBasedError(t *testing.T) {
 		t.Errorf("Expected 'value error', got: %s", result.Cause.Message)
 	}
 }
+
+// causerError implements the legacy Cause() convention without Unwrap.
+type causerError struct {
+	msg   string
+	cause error
+}
+
+func (e *causerError) Error() string { return e.msg }
+func (e *causerError) Cause() error  { return e.cause }
+
+func TestMarshal_WithFollowCause(t *testing.T) {
+	err := errx.Wrap("request failed", &causerError{msg: "legacy", cause: errx.Classify(errors.New("root"), ErrNotFoundTest)})
+
+	withoutOption := errxjson.ToSerializedError(err)
+	if withoutOption.Cause == nil || withoutOption.Cause.Cause != nil {
+		t.Fatal("expected cause chain to stop at the legacy error by default")
+	}
+
+	result := errxjson.ToSerializedError(err, errxjson.WithFollowCause(true))
+	if result.Cause == nil || result.Cause.Cause == nil {
+		t.Fatal("expected cause chain to follow Cause()")
+	}
+	if result.Cause.Cause.Message != "root" {
+		t.Errorf("expected root message, got %q", result.Cause.Cause.Message)
+	}
+}
+
+func TestMarshal_WithFollowCause_SelfCause(t *testing.T) {
+	legacy := &causerError{msg: "loop"}
+	legacy.cause = legacy
+
+	data, err := errxjson.Marshal(legacy, errxjson.WithFollowCause(true))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	if len(data) == 0 {
+		t.Error("expected output")
+	}
+}
+
+func TestMarshal_SeverityIsNotSentinel(t *testing.T) {
+	err := errx.Classify(errors.New("boom"), ErrNotFoundTest, errx.Severity(slog.LevelWarn))
+
+	serialized := errxjson.ToSerializedError(err)
+	if len(serialized.Sentinels) != 1 || serialized.Sentinels[0] != "not found" {
+		t.Errorf("Sentinels = %v, want [not found]", serialized.Sentinels)
+	}
+}
+
+func TestMarshal_PropagateIsTransparent(t *testing.T) {
+	inner := errx.Classify(errors.New("row missing"), ErrNotFoundTest)
+
+	for _, includeStandard := range []bool{false, true} {
+		t.Run(fmt.Sprintf("includeStandardErrors=%v", includeStandard), func(t *testing.T) {
+			opt := errxjson.WithIncludeStandardErrors(includeStandard)
+			plain, err := errxjson.Marshal(errx.Wrap("load", inner, ErrDatabaseTest), opt)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			propagated, err := errxjson.Marshal(errx.Wrap("load", stacktrace.Propagate(stacktrace.Propagate(inner)), ErrDatabaseTest), opt)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			if string(propagated) != string(plain) {
+				t.Errorf("Propagate changed the output\nwithout: %s\nwith:    %s", plain, propagated)
+			}
+		})
+	}
+
+	serialized := errxjson.ToSerializedError(stacktrace.Propagate(errx.Wrap("load", stacktrace.Propagate(inner), ErrDatabaseTest)))
+	if got := strings.Join(serialized.Sentinels, ","); got != "database,not found" {
+		t.Errorf("Sentinels = %v, want [database not found]", serialized.Sentinels)
+	}
+}
@@ -0,0 +1,211 @@
+//go:build !errx_notrace
+
+package json_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-extras/errx"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestMarshal_StackTrace(t *testing.T) {
+	testErr := stacktrace.Wrap("operation failed", errors.New("base error"), ErrDatabaseTest)
+
+	data, err := errxjson.Marshal(testErr)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var result errxjson.SerializedError
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if len(result.StackTrace) == 0 {
+		t.Error("StackTrace is empty, want non-empty")
+	}
+
+	// Check first frame has required fields
+	if len(result.StackTrace) > 0 {
+		frame := result.StackTrace[0]
+		if frame.File == "" {
+			t.Error("Frame.File is empty")
+		}
+		if frame.Line == 0 {
+			t.Error("Frame.Line is 0")
+		}
+		if frame.Function == "" {
+			t.Error("Frame.Function is empty")
+		}
+	}
+}
+
+func TestMarshal_GoroutineAncestors(t *testing.T) {
+	results := make(chan error, 1)
+	stacktrace.Go(context.Background(), func(ctx context.Context) {
+		results <- stacktrace.WrapCtx(ctx, "job failed", errors.New("base error"))
+	})
+	testErr := <-results
+
+	data, err := errxjson.Marshal(testErr, errxjson.WithMaxStackFrames(2))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var result errxjson.SerializedError
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if len(result.GoroutineAncestors) != 1 {
+		t.Fatalf("len(GoroutineAncestors) = %d, want 1", len(result.GoroutineAncestors))
+	}
+	spawn := result.GoroutineAncestors[0]
+	if len(spawn) == 0 || len(spawn) > 2 {
+		t.Errorf("len(GoroutineAncestors[0]) = %d, want 1..2", len(spawn))
+	}
+	if len(spawn) > 0 && spawn[0].Function != "github.com/go-extras/errx/json_test.TestMarshal_GoroutineAncestors" {
+		t.Errorf("GoroutineAncestors[0][0].Function = %q, want test function", spawn[0].Function)
+	}
+}
+
+func TestMarshal_ComplexError(t *testing.T) {
+	// Create a complex error with all features
+	baseErr := errors.New("connection failed")
+	displayErr := errx.NewDisplayable("Service temporarily unavailable")
+	attrErr := errx.Attrs("retry_count", 3, "host", "localhost")
+
+	testErr := stacktrace.Wrap("database operation failed",
+		errx.Classify(baseErr, displayErr, attrErr, ErrTimeoutTest))
+
+	data, err := errxjson.Marshal(testErr)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var result errxjson.SerializedError
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	// Verify all components are present
+	if result.DisplayText != "Service temporarily unavailable" {
+		t.Errorf("DisplayText = %q, want %q", result.DisplayText, "Service temporarily unavailable")
+	}
+	if len(result.Attributes) != 2 {
+		t.Errorf("len(Attributes) = %d, want 2", len(result.Attributes))
+	}
+	if len(result.StackTrace) == 0 {
+		t.Error("StackTrace is empty")
+	}
+	if len(result.Sentinels) == 0 {
+		t.Error("Sentinels is empty")
+	}
+}
+
+func TestMarshal_UnhashableError(t *testing.T) {
+	// Create an unhashable error (contains a map field)
+	unhashableErr := &unhashableError{
+		message: "validation failed",
+		data: map[string]any{
+			"email": "required",
+			"age":   "must be 18 or older",
+		},
+	}
+
+	// Wrap it with errx - this should not panic
+	wrappedErr := stacktrace.Wrap("operation failed", unhashableErr)
+
+	// Marshal should handle unhashable errors gracefully
+	data, err := errxjson.Marshal(wrappedErr)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var result errxjson.SerializedError
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	// Verify the error was serialized correctly
+	if result.Message != "operation failed: validation failed" {
+		t.Errorf("Message = %q, want %q", result.Message, "operation failed: validation failed")
+	}
+
+	// Verify the cause was included
+	if result.Cause == nil {
+		t.Fatal("Cause should not be nil")
+	}
+
+	if result.Cause.Message != "validation failed" {
+		t.Errorf("Cause.Message = %q, want %q", result.Cause.Message, "validation failed")
+	}
+
+	// Verify stack trace was captured
+	if len(result.StackTrace) == 0 {
+		t.Error("StackTrace should not be empty")
+	}
+}
+
+func TestWithSourceContext(t *testing.T) {
+	testErr := stacktrace.Wrap("operation failed", errors.New("base error"))
+
+	data, err := errxjson.Marshal(testErr, errxjson.WithSourceContext(1), errxjson.WithMaxStackFrames(1))
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+
+	var result errxjson.SerializedError
+	if err := json.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+
+	if len(result.StackTrace) != 1 {
+		t.Fatalf("len(StackTrace) = %d, want 1", len(result.StackTrace))
+	}
+	context := result.StackTrace[0].Context
+	if len(context) != 3 {
+		t.Fatalf("len(Context) = %d, want 3", len(context))
+	}
+	if !context[1].Current || context[0].Current || context[2].Current {
+		t.Errorf("Context current markers = %+v, want only the middle line", context)
+	}
+	if context[1].Line != result.StackTrace[0].Line {
+		t.Errorf("Context[1].Line = %d, want %d", context[1].Line, result.StackTrace[0].Line)
+	}
+	if !strings.Contains(context[1].Text, "stacktrace.Wrap(") {
+		t.Errorf("Context[1].Text = %q, want the failing line", context[1].Text)
+	}
+}
+
+func TestWithSourceFS(t *testing.T) {
+	testErr := stacktrace.Wrap("operation failed", errors.New("base error"))
+	_, file, _, _ := runtime.Caller(0)
+	source, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile error: %v", err)
+	}
+	fsys := fstest.MapFS{"json/json_trace_test.go": &fstest.MapFile{Data: source}}
+
+	result := errxjson.ToSerializedError(testErr,
+		errxjson.WithSourceContext(2), errxjson.WithSourceFS(fsys), errxjson.WithMaxStackFrames(2))
+
+	if len(result.StackTrace) != 2 {
+		t.Fatalf("len(StackTrace) = %d, want 2", len(result.StackTrace))
+	}
+	if len(result.StackTrace[0].Context) != 5 {
+		t.Errorf("len(StackTrace[0].Context) = %d, want 5", len(result.StackTrace[0].Context))
+	}
+	if result.StackTrace[1].Context != nil {
+		t.Errorf("StackTrace[1].Context = %v, want nil for a file outside the FS", result.StackTrace[1].Context)
+	}
+}
@@ -1,5 +1,9 @@
 package json
 
+import (
+	"io/fs"
+)
+
 // Option is a function that configures the JSON serialization behavior.
 type Option func(*config)
 
@@ -51,3 +55,51 @@ func WithIncludeStandardErrors(include bool) Option {
 		c.includeStandardErrors = include
 	}
 }
+
+// WithSourceContext includes the given number of source lines before and after
+// each stack frame's line in the frame's "context" field. The default is 0
+// (no source context).
+//
+// Sources are read from disk unless WithSourceFS is used. Frames whose source
+// is not available are serialized without context.
+//
+// Example:
+//
+//	jsonBytes, err := json.Marshal(err, json.WithSourceContext(3))
+func WithSourceContext(lines int) Option {
+	return func(c *config) {
+		c.sourceLines = lines
+	}
+}
+
+// WithSourceFS reads source context from fsys instead of from disk.
+// It has no effect unless WithSourceContext is also used.
+// See stacktrace.SourceReader for how frame paths are looked up in fsys.
+//
+// Example:
+//
+//	jsonBytes, err := json.Marshal(err,
+//	    json.WithSourceContext(3),
+//	    json.WithSourceFS(embeddedSources))
+func WithSourceFS(fsys fs.FS) Option {
+	return func(c *config) {
+		c.sourceFS = fsys
+	}
+}
+
+// WithFollowCause makes the serializer continue the cause chain through errors
+// that implement the legacy Cause() error method but not Unwrap, as found in
+// libraries predating Go 1.13 error wrapping. The default is false.
+//
+// Only the serialized cause chain is affected; display text, attributes and
+// stack traces are still extracted through Unwrap. Use compat.Adapt to lift such
+// chains for all errx functions.
+//
+// Example:
+//
+//	jsonBytes, err := json.Marshal(err, json.WithFollowCause(true))
+func WithFollowCause(follow bool) Option {
+	return func(c *config) {
+		c.followCause = follow
+	}
+}
@@ -0,0 +1,88 @@
+package json
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/tracectx"
+)
+
+// ProblemContentType is the media type of RFC 9457 problem details.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 9457 problem details object describing an error to API clients.
+//
+// Unlike SerializedError, a Problem never includes the error message, attributes or
+// stack trace, which may contain internal details. Only the display text is exposed,
+// along with the trace and span IDs so that clients can report them.
+type Problem struct {
+	// Type is a URI reference identifying the problem type
+	Type string `json:"type,omitempty"`
+
+	// Title is a short summary of the problem type
+	Title string `json:"title,omitempty"`
+
+	// Status is the HTTP status code
+	Status int `json:"status,omitempty"`
+
+	// Detail is the display text of the error, if it is displayable
+	Detail string `json:"detail,omitempty"`
+
+	// Instance is a URI reference identifying this occurrence of the problem
+	Instance string `json:"instance,omitempty"`
+
+	// TraceID and SpanID are the tracing identifiers attached with the tracectx package
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+}
+
+// NewProblem builds the problem details for err with the given HTTP status.
+// The title is the status text and the detail is the display text of err.
+// It returns nil for nil errors.
+//
+// Example:
+//
+//	p := json.NewProblem(err, http.StatusNotFound)
+//	p.Type = "https://example.com/problems/not-found"
+func NewProblem(err error, status int) *Problem {
+	if err == nil {
+		return nil
+	}
+	p := &Problem{
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+	if errx.IsDisplayable(err) {
+		p.Detail = errx.DisplayText(err)
+	}
+	p.TraceID, p.SpanID = tracectx.IDs(err)
+	return p
+}
+
+// WriteProblem writes p as an application/problem+json response with status p.Status,
+// or 500 Internal Server Error if p is nil or has no status.
+//
+// Example:
+//
+//	if err != nil {
+//	    json.WriteProblem(w, json.NewProblem(err, http.StatusInternalServerError))
+//	    return
+//	}
+func WriteProblem(w http.ResponseWriter, p *Problem) error {
+	if p == nil {
+		p = &Problem{Title: http.StatusText(http.StatusInternalServerError)}
+	}
+	body, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	w.WriteHeader(status)
+	_, err = w.Write(body)
+	return err
+}
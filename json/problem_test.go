@@ -0,0 +1,107 @@
+package json_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-extras/errx"
+	errxjson "github.com/go-extras/errx/json"
+	"github.com/go-extras/errx/tracectx"
+)
+
+var testSpan = tracectx.SpanContext{
+	TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+	SpanID:  "00f067aa0ba902b7",
+}
+
+func TestMarshal_TraceIDs(t *testing.T) {
+	ctx := tracectx.ContextWithSpan(context.Background(), testSpan)
+	err := tracectx.WrapCtx(ctx, "load order", errors.New("db down"), ErrDatabaseTest)
+
+	s := errxjson.ToSerializedError(err)
+	if s.TraceID != testSpan.TraceID || s.SpanID != testSpan.SpanID {
+		t.Errorf("trace IDs = %q, %q, want %q, %q", s.TraceID, s.SpanID, testSpan.TraceID, testSpan.SpanID)
+	}
+	if s.Cause.TraceID != "" {
+		t.Errorf("cause TraceID = %q, want empty", s.Cause.TraceID)
+	}
+
+	data, marshalErr := errxjson.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("Marshal error: %v", marshalErr)
+	}
+	var decoded map[string]any
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("Unmarshal error: %v", unmarshalErr)
+	}
+	if decoded["trace_id"] != testSpan.TraceID || decoded["span_id"] != testSpan.SpanID {
+		t.Errorf("JSON = %s, want trace_id and span_id fields", data)
+	}
+}
+
+func TestNewProblem(t *testing.T) {
+	ctx := tracectx.ContextWithSpan(context.Background(), testSpan)
+	cause := errx.Classify(errors.New("sql: no rows"), errx.NewDisplayable("Order not found"))
+	err := tracectx.WrapCtx(ctx, "load order", cause, ErrNotFoundTest)
+
+	p := errxjson.NewProblem(err, http.StatusNotFound)
+	want := errxjson.Problem{
+		Title:   "Not Found",
+		Status:  http.StatusNotFound,
+		Detail:  "Order not found",
+		TraceID: testSpan.TraceID,
+		SpanID:  testSpan.SpanID,
+	}
+	if *p != want {
+		t.Errorf("NewProblem() = %+v, want %+v", *p, want)
+	}
+}
+
+func TestNewProblem_HidesInternalMessage(t *testing.T) {
+	p := errxjson.NewProblem(errors.New("dial tcp 10.0.0.5:5432: refused"), http.StatusInternalServerError)
+	if p.Detail != "" {
+		t.Errorf("Detail = %q, want empty for non-displayable errors", p.Detail)
+	}
+}
+
+func TestNewProblem_Nil(t *testing.T) {
+	if p := errxjson.NewProblem(nil, http.StatusNotFound); p != nil {
+		t.Errorf("NewProblem(nil) = %+v, want nil", p)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	p := &errxjson.Problem{Title: "Conflict", Status: http.StatusConflict, TraceID: testSpan.TraceID}
+	if err := errxjson.WriteProblem(rec, p); err != nil {
+		t.Fatalf("WriteProblem error: %v", err)
+	}
+
+	if rec.Code != http.StatusConflict {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != errxjson.ProblemContentType {
+		t.Errorf("Content-Type = %q, want %q", ct, errxjson.ProblemContentType)
+	}
+	want := `{"title":"Conflict","status":409,"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"}`
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
+
+func TestWriteProblem_Defaults(t *testing.T) {
+	rec := httptest.NewRecorder()
+	if err := errxjson.WriteProblem(rec, nil); err != nil {
+		t.Fatalf("WriteProblem error: %v", err)
+	}
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got, want := rec.Body.String(), `{"title":"Internal Server Error"}`; got != want {
+		t.Errorf("body = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,96 @@
+package errx
+
+import (
+	"errors"
+)
+
+// MostSpecific returns the most specific of the candidate sentinels matching err
+// with errors.Is, or nil if none matches.
+//
+// Specificity is the depth of a sentinel in the hierarchy: the length of the longest
+// path through its parents. A sentinel is therefore always more specific than its
+// ancestors, whatever order the candidates are given in. Among equally deep
+// candidates, the first one wins.
+//
+// Example:
+//
+//	var (
+//	    ErrDatabase = errx.NewSentinel("database")
+//	    ErrTimeout  = errx.NewSentinel("timeout", ErrDatabase)
+//	)
+//
+//	err := errx.Classify(cause, ErrTimeout)
+//	errx.MostSpecific(err, ErrDatabase, ErrTimeout) // ErrTimeout
+func MostSpecific(err error, candidates ...Classified) Classified {
+	i := mostSpecificIndex(err, candidates)
+	if i < 0 {
+		return nil
+	}
+	return candidates[i]
+}
+
+// mostSpecificIndex returns the index of the most specific candidate matching err, or -1.
+func mostSpecificIndex(err error, candidates []Classified) int {
+	best, bestDepth := -1, -1
+	for i, candidate := range candidates {
+		if candidate == nil || !errors.Is(err, candidate) {
+			continue
+		}
+		if depth := hierarchyDepth(candidate); depth > bestDepth {
+			best, bestDepth = i, depth
+		}
+	}
+	return best
+}
+
+// Matcher dispatches an error to the handler of the most specific matching sentinel.
+// Create one with Match.
+type Matcher struct {
+	err      error
+	cases    []Classified
+	handlers []func(error)
+}
+
+// Match returns a Matcher for err. Register handlers with Case and dispatch with
+// Default or Run. Only the handler of the most specific matching sentinel runs,
+// as determined by MostSpecific, so cases can be listed in any order:
+//
+//	errx.Match(err).
+//	    Case(ErrDatabase, func(err error) { status = http.StatusServiceUnavailable }).
+//	    Case(ErrTimeout, func(err error) { status = http.StatusGatewayTimeout }). // child of ErrDatabase
+//	    Case(ErrNotFound, func(err error) { status = http.StatusNotFound }).
+//	    Default(func(err error) { status = http.StatusInternalServerError })
+func Match(err error) *Matcher {
+	return &Matcher{err: err}
+}
+
+// Case registers fn as the handler for errors matching sentinel.
+func (m *Matcher) Case(sentinel Classified, fn func(err error)) *Matcher {
+	m.cases = append(m.cases, sentinel)
+	m.handlers = append(m.handlers, fn)
+	return m
+}
+
+// Default dispatches the error, calling fn if no case matches.
+// If the error is nil, no handler is called.
+func (m *Matcher) Default(fn func(err error)) {
+	if !m.Run() && m.err != nil && fn != nil {
+		fn(m.err)
+	}
+}
+
+// Run dispatches the error to the most specific matching case and reports whether
+// a case matched. If the error is nil, no handler is called.
+func (m *Matcher) Run() bool {
+	if m.err == nil {
+		return false
+	}
+	i := mostSpecificIndex(m.err, m.cases)
+	if i < 0 {
+		return false
+	}
+	if m.handlers[i] != nil {
+		m.handlers[i](m.err)
+	}
+	return true
+}
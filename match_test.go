@@ -0,0 +1,106 @@
+package errx_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/compat"
+)
+
+var (
+	errMatchDatabase  = errx.NewSentinel("database")
+	errMatchRetryable = errx.NewSentinel("retryable")
+	errMatchTimeout   = errx.NewSentinel("timeout", errMatchDatabase, errMatchRetryable)
+	errMatchDeadlock  = errx.NewSentinel("deadlock", errMatchTimeout)
+	errMatchNotFound  = errx.NewSentinel("not found")
+	errMatchMarked    = compat.MarkTimeout(errx.NewSentinel("marked", errMatchDatabase))
+)
+
+func TestMostSpecific(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name       string
+		err        error
+		candidates []errx.Classified
+		want       errx.Classified
+	}{
+		{"nil error", nil, []errx.Classified{errMatchDatabase}, nil},
+		{"no candidates", errx.Classify(base, errMatchTimeout), nil, nil},
+		{"no match", errx.Classify(base, errMatchNotFound), []errx.Classified{errMatchDatabase}, nil},
+		{"general first", errx.Classify(base, errMatchTimeout), []errx.Classified{errMatchDatabase, errMatchTimeout}, errMatchTimeout},
+		{"specific first", errx.Classify(base, errMatchTimeout), []errx.Classified{errMatchTimeout, errMatchDatabase}, errMatchTimeout},
+		{"grandchild", errx.Classify(base, errMatchDeadlock), []errx.Classified{errMatchRetryable, errMatchDeadlock, errMatchTimeout}, errMatchDeadlock},
+		{"ancestor only", errx.Classify(base, errMatchDeadlock), []errx.Classified{errMatchNotFound, errMatchRetryable}, errMatchRetryable},
+		{"equal depth keeps order", errx.Classify(base, errMatchNotFound, errMatchDatabase), []errx.Classified{errMatchNotFound, errMatchDatabase}, errMatchNotFound},
+		{"wrapped sentinel", errx.Classify(base, errMatchMarked), []errx.Classified{errMatchDatabase, errMatchMarked}, errMatchMarked},
+		{"nil candidate", errx.Classify(base, errMatchDatabase), []errx.Classified{nil, errMatchDatabase}, errMatchDatabase},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errx.MostSpecific(tt.err, tt.candidates...); got != tt.want {
+				t.Errorf("MostSpecific() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	dispatch := func(err error) string {
+		result := "none"
+		errx.Match(err).
+			Case(errMatchDatabase, func(error) { result = "database" }).
+			Case(errMatchRetryable, func(error) { result = "retryable" }).
+			Case(errMatchTimeout, func(error) { result = "timeout" }).
+			Case(errMatchNotFound, func(error) { result = "not found" }).
+			Default(func(error) { result = "default" })
+		return result
+	}
+
+	base := errors.New("boom")
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{errx.Classify(base, errMatchDatabase), "database"},
+		{errx.Classify(base, errMatchTimeout), "timeout"},
+		{errx.Classify(base, errMatchDeadlock), "timeout"},
+		{errx.Wrap("lookup", errx.Classify(base, errMatchNotFound)), "not found"},
+		{base, "default"},
+		{nil, "none"},
+	}
+	for _, tt := range tests {
+		if got := dispatch(tt.err); got != tt.want {
+			t.Errorf("dispatch(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestMatch_HandlerReceivesError(t *testing.T) {
+	err := errx.Wrap("lookup", errors.New("boom"), errMatchNotFound)
+
+	var got error
+	errx.Match(err).Case(errMatchNotFound, func(e error) { got = e }).Default(nil)
+	if got != err {
+		t.Errorf("handler received %v, want %v", got, err)
+	}
+}
+
+func TestMatch_Run(t *testing.T) {
+	calls := 0
+	m := errx.Match(errx.Classify(errors.New("boom"), errMatchTimeout)).
+		Case(errMatchDatabase, func(error) { calls++ }).
+		Case(errMatchTimeout, func(error) { calls++ })
+	if !m.Run() || calls != 1 {
+		t.Errorf("Run() called %d handlers, want 1", calls)
+	}
+
+	if errx.Match(errors.New("boom")).Case(errMatchDatabase, func(error) { calls++ }).Run() {
+		t.Error("Run() = true for an unmatched error")
+	}
+	if !errx.Match(errx.Classify(errors.New("boom"), errMatchDatabase)).Case(errMatchDatabase, nil).Run() {
+		t.Error("Run() = false for a matching case without handler")
+	}
+}
@@ -0,0 +1,29 @@
+package otelconv_test
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/otelconv"
+)
+
+// ExampleAttrs demonstrates converting an error into exception attributes
+func ExampleAttrs() {
+	ErrNotFound := errx.NewSentinel("not found")
+
+	cause := errx.Classify(errors.New("no rows"), errx.NewDisplayable("Order not found"))
+	err := errx.Wrap("load order", cause, ErrNotFound, errx.Attrs("order_id", 42))
+
+	for _, attr := range otelconv.Attrs(err) {
+		fmt.Println(attr)
+	}
+
+	// Output:
+	// exception.type=*errors.errorString
+	// exception.message=load order: no rows
+	// exception.escaped=false
+	// error.codes=[not found]
+	// error.display_text=Order not found
+	// error.attributes.order_id=42
+}
@@ -0,0 +1,203 @@
+// Package otelconv converts errx errors into the attributes defined by the
+// OpenTelemetry semantic conventions for exceptions, without depending on OpenTelemetry.
+//
+// The result can be recorded as an "exception" span event by any tracer adapter,
+// or logged with slog. Besides the exception.* attributes, errx-specific keys carry
+// the sentinels, display text and attributes of the error.
+//
+// # Basic Usage
+//
+//	attrs := otelconv.Attrs(err)
+//	logger.LogAttrs(ctx, slog.LevelError, "request failed", attrs...)
+//
+// # Recording a Span Event
+//
+//	// With go.opentelemetry.io/otel
+//	var kvs []attribute.KeyValue
+//	for k, v := range otelconv.Map(err, otelconv.WithEscaped(true)) {
+//	    switch v := v.(type) {
+//	    case string:
+//	        kvs = append(kvs, attribute.String(k, v))
+//	    case bool:
+//	        kvs = append(kvs, attribute.Bool(k, v))
+//	    case []string:
+//	        kvs = append(kvs, attribute.StringSlice(k, v))
+//	    default:
+//	        kvs = append(kvs, attribute.String(k, fmt.Sprint(v)))
+//	    }
+//	}
+//	span.AddEvent("exception", trace.WithAttributes(kvs...))
+package otelconv
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/internal/errptr"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// Attribute keys defined by the OpenTelemetry exception semantic conventions.
+const (
+	ExceptionType       = "exception.type"
+	ExceptionMessage    = "exception.message"
+	ExceptionStacktrace = "exception.stacktrace"
+	ExceptionEscaped    = "exception.escaped"
+)
+
+// errx-specific attribute keys.
+const (
+	// ErrorCodes holds the texts of the sentinels attached to the error, outermost first
+	ErrorCodes = "error.codes"
+
+	// ErrorDisplayText holds the display text of the error
+	ErrorDisplayText = "error.display_text"
+
+	// ErrorAttributePrefix prefixes the keys of the error's attributes
+	ErrorAttributePrefix = "error.attributes."
+)
+
+// Option configures the conversion.
+type Option func(*config)
+
+// config holds conversion configuration.
+type config struct {
+	escaped        bool
+	maxStackFrames int
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *config {
+	return &config{
+		maxStackFrames: 32,
+	}
+}
+
+// WithEscaped sets the exception.escaped attribute, which reports whether the error
+// escapes the scope of the span, for example when it is returned from the handler
+// the span covers. The default is false.
+func WithEscaped(escaped bool) Option {
+	return func(c *config) {
+		c.escaped = escaped
+	}
+}
+
+// WithMaxStackFrames limits the number of frames in exception.stacktrace.
+// The default is 32. Zero or a negative value means no limit.
+func WithMaxStackFrames(n int) Option {
+	return func(c *config) {
+		c.maxStackFrames = n
+	}
+}
+
+// Attrs converts err into exception semantic convention attributes, in this order:
+//
+//   - exception.type: the Go type of the innermost error in the chain, such as "*net.OpError"
+//   - exception.message: err.Error()
+//   - exception.stacktrace: the stack trace, if one was captured
+//   - exception.escaped: see WithEscaped
+//   - error.codes: the texts of the attached sentinels, if any, as a []string
+//   - error.display_text: the display text, if the error is displayable
+//   - error.attributes.<key>: each attribute of the error
+//
+// If an attribute key occurs several times in the chain, the outermost value is used.
+// It returns nil for nil errors.
+func Attrs(err error, opts ...Option) []slog.Attr {
+	if err == nil {
+		return nil
+	}
+
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	attrs := []slog.Attr{
+		slog.String(ExceptionType, typeName(rootCause(err))),
+		slog.String(ExceptionMessage, err.Error()),
+	}
+	if frames := stacktrace.Extract(err); len(frames) > 0 {
+		attrs = append(attrs, slog.String(ExceptionStacktrace, formatStack(frames, cfg.maxStackFrames)))
+	}
+	attrs = append(attrs, slog.Bool(ExceptionEscaped, cfg.escaped))
+
+	if sentinels := errx.Sentinels(err); len(sentinels) > 0 {
+		codes := make([]string, len(sentinels))
+		for i, s := range sentinels {
+			codes[i] = s.Error()
+		}
+		attrs = append(attrs, slog.Any(ErrorCodes, codes))
+	}
+	if errx.IsDisplayable(err) {
+		attrs = append(attrs, slog.String(ErrorDisplayText, errx.DisplayText(err)))
+	}
+
+	seen := make(map[string]bool)
+	for _, attr := range errx.ExtractAttrs(err) {
+		if seen[attr.Key] {
+			continue
+		}
+		seen[attr.Key] = true
+		attrs = append(attrs, slog.Any(ErrorAttributePrefix+attr.Key, attr.Value))
+	}
+
+	return attrs
+}
+
+// Map is like Attrs, but returns the attributes as a map from key to value.
+// Values are strings, bools, []string or the attribute values of the error.
+// It returns nil for nil errors.
+func Map(err error, opts ...Option) map[string]any {
+	attrs := Attrs(err, opts...)
+	if attrs == nil {
+		return nil
+	}
+	result := make(map[string]any, len(attrs))
+	for _, attr := range attrs {
+		result[attr.Key] = attr.Value.Any()
+	}
+	return result
+}
+
+// rootCause follows Unwrap() error to the innermost error. Multi-errors are not descended into.
+func rootCause(err error) error {
+	visited := map[uintptr]bool{errptr.Get(err): true}
+	for {
+		next := errors.Unwrap(err)
+		if next == nil || visited[errptr.Get(next)] {
+			return err
+		}
+		visited[errptr.Get(next)] = true
+		err = next
+	}
+}
+
+// typeName returns the fully qualified type name of err, such as "*net.OpError".
+func typeName(err error) string {
+	t := reflect.TypeOf(err)
+	prefix := ""
+	for t.Kind() == reflect.Pointer {
+		prefix += "*"
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" || t.Name() == "" {
+		return prefix + t.String()
+	}
+	return prefix + t.PkgPath() + "." + t.Name()
+}
+
+// formatStack renders frames like a Go panic trace.
+func formatStack(frames []stacktrace.Frame, limit int) string {
+	if limit > 0 && len(frames) > limit {
+		frames = frames[:limit]
+	}
+	var b strings.Builder
+	for _, frame := range frames {
+		fmt.Fprintf(&b, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+	}
+	return b.String()
+}
@@ -0,0 +1,99 @@
+package otelconv_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/otelconv"
+)
+
+var (
+	ErrDatabase = errx.NewSentinel("database")
+	ErrTimeout  = errx.NewSentinel("timeout", ErrDatabase)
+	ErrNotFound = errx.NewSentinel("not found")
+)
+
+func TestAttrs(t *testing.T) {
+	cause := &net.AddrError{Err: "missing port in address", Addr: "db"}
+	inner := errx.Classify(cause, ErrNotFound, errx.NewDisplayable("File not found"), errx.Attrs("path", "/data"))
+	err := errx.Wrap("load config", inner, ErrTimeout, errx.Attrs("attempt", 2))
+
+	got := otelconv.Map(err)
+	want := map[string]any{
+		otelconv.ExceptionType:                    "*net.AddrError",
+		otelconv.ExceptionMessage:                 "load config: address db: missing port in address",
+		otelconv.ExceptionEscaped:                 false,
+		otelconv.ErrorCodes:                       []string{"timeout", "not found"},
+		otelconv.ErrorDisplayText:                 "File not found",
+		otelconv.ErrorAttributePrefix + "attempt": int64(2),
+		otelconv.ErrorAttributePrefix + "path":    "/data",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %#v\nwant %#v", got, want)
+	}
+}
+
+func TestAttrs_Order(t *testing.T) {
+	err := errx.Classify(errors.New("boom"), ErrNotFound, errx.Attrs("k", "v"))
+
+	var keys []string
+	for _, attr := range otelconv.Attrs(err) {
+		keys = append(keys, attr.Key)
+	}
+	want := []string{
+		otelconv.ExceptionType,
+		otelconv.ExceptionMessage,
+		otelconv.ExceptionEscaped,
+		otelconv.ErrorCodes,
+		otelconv.ErrorAttributePrefix + "k",
+	}
+	if !reflect.DeepEqual(keys, want) {
+		t.Errorf("keys = %v, want %v", keys, want)
+	}
+}
+
+func TestAttrs_DuplicateKeys(t *testing.T) {
+	inner := errx.Classify(errors.New("boom"), errx.Attrs("user_id", "inner"))
+	err := errx.Wrap("outer", inner, errx.Attrs("user_id", "outer"))
+
+	if got := otelconv.Map(err)[otelconv.ErrorAttributePrefix+"user_id"]; got != "outer" {
+		t.Errorf("user_id = %v, want the outermost value", got)
+	}
+}
+
+func TestAttrs_StandardError(t *testing.T) {
+	got := otelconv.Map(fmt.Errorf("wrapped: %w", errors.New("plain")), otelconv.WithEscaped(true))
+	want := map[string]any{
+		otelconv.ExceptionType:    "*errors.errorString",
+		otelconv.ExceptionMessage: "wrapped: plain",
+		otelconv.ExceptionEscaped: true,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Map() = %#v, want %#v", got, want)
+	}
+}
+
+// codeError is a value-type error
+type codeError int
+
+func (e codeError) Error() string { return fmt.Sprintf("code %d", int(e)) }
+
+func TestAttrs_ValueType(t *testing.T) {
+	got := otelconv.Map(errx.Classify(codeError(7), ErrNotFound))[otelconv.ExceptionType]
+	if got != "github.com/go-extras/errx/otelconv_test.codeError" {
+		t.Errorf("exception.type = %v", got)
+	}
+}
+
+func TestAttrs_Nil(t *testing.T) {
+	if got := otelconv.Attrs(nil); got != nil {
+		t.Errorf("Attrs(nil) = %v, want nil", got)
+	}
+	if got := otelconv.Map(nil); got != nil {
+		t.Errorf("Map(nil) = %v, want nil", got)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build !errx_notrace
+
+package otelconv_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx/otelconv"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestAttrs_Stacktrace(t *testing.T) {
+	err := stacktrace.Wrap("failed", errors.New("boom"), ErrDatabase)
+
+	trace, _ := otelconv.Map(err)[otelconv.ExceptionStacktrace].(string)
+	if !strings.HasPrefix(trace, "github.com/go-extras/errx/otelconv_test.TestAttrs_Stacktrace\n\t") {
+		t.Errorf("exception.stacktrace = %q, want the capturing function first", trace)
+	}
+	if !strings.Contains(trace, "otelconv_trace_test.go:") {
+		t.Errorf("exception.stacktrace = %q, want file and line", trace)
+	}
+
+	limited, _ := otelconv.Map(err, otelconv.WithMaxStackFrames(1))[otelconv.ExceptionStacktrace].(string)
+	if strings.Count(limited, "\n") != 2 {
+		t.Errorf("limited exception.stacktrace = %q, want one frame", limited)
+	}
+}
@@ -0,0 +1,116 @@
+package report
+
+import (
+	"context"
+	"os"
+	"runtime/debug"
+	"sync"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/tracectx"
+)
+
+// Build describes the binary reporting an error, as recorded by the Go toolchain.
+type Build struct {
+	// Module is the path of the main module
+	Module string `json:"module,omitempty"`
+
+	// Version is the version of the main module, such as "v1.2.3" or "(devel)"
+	Version string `json:"version,omitempty"`
+
+	// Revision is the VCS revision the binary was built from, and Modified reports
+	// whether the working tree had uncommitted changes
+	Revision string `json:"revision,omitempty"`
+	Modified bool   `json:"modified,omitempty"`
+
+	// GoVersion is the version of the Go toolchain that built the binary
+	GoVersion string `json:"go_version,omitempty"`
+}
+
+// DefaultEnrichers returns the enrichers used by a Reporter unless replaced with
+// WithEnrichers: Fingerprint(nil), TraceIDs(), Host() and BuildInfo().
+func DefaultEnrichers() []Enricher {
+	return []Enricher{Fingerprint(nil), TraceIDs(), Host(), BuildInfo()}
+}
+
+// Fingerprint returns an Enricher setting Event.Fingerprint with f, or with
+// errx.Fingerprint if f is nil.
+func Fingerprint(f *errx.Fingerprinter) Enricher {
+	return EnricherFunc(func(_ context.Context, e *Event) {
+		if f == nil {
+			e.Fingerprint = errx.Fingerprint(e.Err)
+			return
+		}
+		e.Fingerprint = f.Fingerprint(e.Err)
+	})
+}
+
+// TraceIDs returns an Enricher setting Event.TraceID and Event.SpanID to the IDs
+// attached to the error with the tracectx package, or else to those of the context
+// passed to Reporter.Report (see tracectx.FromContext).
+func TraceIDs() Enricher {
+	return EnricherFunc(func(ctx context.Context, e *Event) {
+		e.TraceID, e.SpanID = tracectx.IDs(e.Err)
+		if e.TraceID != "" {
+			return
+		}
+		if sc, ok := tracectx.FromContext(ctx); ok && sc.IsValid() {
+			e.TraceID, e.SpanID = sc.TraceID, sc.SpanID
+		}
+	})
+}
+
+// Host returns an Enricher setting Event.Host to the host name reported by the kernel.
+// The name is looked up once.
+func Host() Enricher {
+	host := sync.OnceValue(func() string {
+		name, _ := os.Hostname()
+		return name
+	})
+	return EnricherFunc(func(_ context.Context, e *Event) {
+		e.Host = host()
+	})
+}
+
+// BuildInfo returns an Enricher setting Event.Build from the build information
+// embedded in the binary (see debug.ReadBuildInfo). The information is read once;
+// Event.Build stays nil if it isn't available.
+func BuildInfo() Enricher {
+	build := sync.OnceValue(readBuild)
+	return EnricherFunc(func(_ context.Context, e *Event) {
+		e.Build = build()
+	})
+}
+
+// readBuild returns the build information of the running binary, or nil.
+func readBuild() *Build {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	b := &Build{
+		Module:    info.Main.Path,
+		Version:   info.Main.Version,
+		GoVersion: info.GoVersion,
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			b.Revision = setting.Value
+		case "vcs.modified":
+			b.Modified = setting.Value == "true"
+		}
+	}
+	return b
+}
+
+// Tag returns an Enricher setting the tag key to value on every event, such as the
+// service name or deployment environment.
+func Tag(key, value string) Enricher {
+	return EnricherFunc(func(_ context.Context, e *Event) {
+		if e.Tags == nil {
+			e.Tags = make(map[string]string)
+		}
+		e.Tags[key] = value
+	})
+}
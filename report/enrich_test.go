@@ -0,0 +1,80 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+	"github.com/go-extras/errx/tracectx"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func enrich(t *testing.T, ctx context.Context, err error, enrichers ...report.Enricher) *report.Event {
+	t.Helper()
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithEnrichers(enrichers...))
+	if err := r.Report(ctx, err); err != nil {
+		t.Fatal(err)
+	}
+	return rec.events[0]
+}
+
+func TestFingerprint(t *testing.T) {
+	err := errors.New("failed")
+	f := errx.NewFingerprinter(errx.WithFingerprintMessage(false))
+
+	if e := enrich(t, context.Background(), err, report.Fingerprint(f)); e.Fingerprint != f.Fingerprint(err) {
+		t.Errorf("expected the fingerprinter to be used, got %q", e.Fingerprint)
+	}
+	if e := enrich(t, context.Background(), err, report.Fingerprint(nil)); e.Fingerprint != errx.Fingerprint(err) {
+		t.Errorf("expected the default fingerprint, got %q", e.Fingerprint)
+	}
+}
+
+func TestTraceIDs(t *testing.T) {
+	sc := tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID}
+	ctx := tracectx.ContextWithSpan(context.Background(), sc)
+
+	// IDs attached to the error come first
+	err := errx.Classify(errors.New("failed"), errx.Attrs(tracectx.TraceIDKey, "0af7651916cd43dd8448eb211c80319c", tracectx.SpanIDKey, "b7ad6b7169203331"))
+	if e := enrich(t, ctx, err, report.TraceIDs()); e.TraceID != "0af7651916cd43dd8448eb211c80319c" || e.SpanID != "b7ad6b7169203331" {
+		t.Errorf("unexpected IDs %q %q", e.TraceID, e.SpanID)
+	}
+
+	// Otherwise the context's
+	if e := enrich(t, ctx, errors.New("failed"), report.TraceIDs()); e.TraceID != testTraceID || e.SpanID != testSpanID {
+		t.Errorf("unexpected IDs %q %q", e.TraceID, e.SpanID)
+	}
+
+	if e := enrich(t, context.Background(), errors.New("failed"), report.TraceIDs()); e.TraceID != "" {
+		t.Errorf("expected no trace ID, got %q", e.TraceID)
+	}
+}
+
+func TestHost(t *testing.T) {
+	want, _ := os.Hostname()
+	if e := enrich(t, context.Background(), errors.New("failed"), report.Host()); e.Host != want {
+		t.Errorf("expected host %q, got %q", want, e.Host)
+	}
+}
+
+func TestBuildInfo(t *testing.T) {
+	e := enrich(t, context.Background(), errors.New("failed"), report.BuildInfo())
+	if e.Build == nil || e.Build.GoVersion == "" {
+		t.Errorf("expected build information for test binaries, got %+v", e.Build)
+	}
+}
+
+func TestTag(t *testing.T) {
+	e := enrich(t, context.Background(), errors.New("failed"), report.Tag("service", "orders"), report.Tag("env", "prod"))
+	if len(e.Tags) != 2 || e.Tags["service"] != "orders" || e.Tags["env"] != "prod" {
+		t.Errorf("unexpected tags %v", e.Tags)
+	}
+}
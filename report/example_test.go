@@ -0,0 +1,39 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+// Example demonstrates a pipeline ignoring expected errors and rate-limiting repeated ones
+func Example() {
+	ErrNotFound := errx.NewSentinel("not found")
+	ErrDatabase := errx.NewSentinel("database")
+
+	sink := report.SinkFunc(func(_ context.Context, e *report.Event) error {
+		fmt.Println(e.Severity, e.Error.Message, e.Error.Sentinels)
+		return nil
+	})
+	reporter := report.New(
+		report.WithSinks(sink),
+		report.WithFilters(
+			report.IgnoreSentinels(ErrNotFound),
+			report.RateLimit(1, time.Minute),
+		),
+	)
+	defer reporter.Close()
+
+	ctx := context.Background()
+	reporter.Report(ctx, errx.Wrap("load order", errors.New("no rows"), ErrNotFound))
+	for range 3 {
+		reporter.Report(ctx, errx.Wrap("save order", errors.New("connection reset"), ErrDatabase))
+	}
+
+	// Output:
+	// ERROR save order: connection reset [database]
+}
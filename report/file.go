@@ -0,0 +1,168 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// FileOption configures a FileSink.
+type FileOption func(*FileSink)
+
+// WithMaxFileSize sets the size in bytes after which the file is rotated.
+// The default is 10 MiB; zero or less disables rotation.
+func WithMaxFileSize(size int64) FileOption {
+	return func(s *FileSink) {
+		s.maxSize = size
+	}
+}
+
+// WithMaxBackups sets how many rotated files are kept, named path.1 (the most
+// recent) to path.N. The default is 3; with zero the file is truncated on rotation.
+func WithMaxBackups(n int) FileOption {
+	return func(s *FileSink) {
+		s.maxBackups = max(n, 0)
+	}
+}
+
+// FileSink writes events as JSON lines to a file, rotating it by size.
+// A FileSink is safe for concurrent use within a process.
+type FileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewFileSink opens (or creates) the file at path for appending events.
+// Close the sink, or the Reporter using it, to release the file.
+//
+// Example:
+//
+//	sink, err := report.NewFileSink("errors.jsonl", report.WithMaxFileSize(50<<20))
+func NewFileSink(path string, opts ...FileOption) (*FileSink, error) {
+	s := &FileSink{
+		path:       path,
+		maxSize:    10 << 20,
+		maxBackups: 3,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write appends e to the file as a single JSON line, rotating the file first if
+// the line would make it exceed the maximum size.
+func (s *FileSink) Write(_ context.Context, e *Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("report: encode event: %w", err)
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return fmt.Errorf("report: write %s: %w", s.path, fs.ErrClosed)
+	}
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("report: write %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// Close closes the file. Writes after Close fail.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// open opens the file for appending and records its current size.
+func (s *FileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("report: open %s: %w", s.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("report: open %s: %w", s.path, err)
+	}
+	s.file = f
+	s.size = info.Size()
+	return nil
+}
+
+// rotate shifts the backups by one, moves the current file to path.1 and opens a new one.
+func (s *FileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return fmt.Errorf("report: rotate %s: %w", s.path, err)
+	}
+	s.file = nil
+
+	var err error
+	if s.maxBackups == 0 {
+		err = os.Remove(s.path)
+	} else {
+		err = removeIfExists(s.backup(s.maxBackups))
+		for i := s.maxBackups - 1; i >= 1 && err == nil; i-- {
+			err = renameIfExists(s.backup(i), s.backup(i+1))
+		}
+		if err == nil {
+			err = os.Rename(s.path, s.backup(1))
+		}
+	}
+	// Keep writing to the current file even if the backups couldn't be shifted
+	if openErr := s.open(); openErr != nil {
+		return openErr
+	}
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("report: rotate %s: %w", s.path, err)
+	}
+	return nil
+}
+
+// backup returns the path of the i-th backup.
+func (s *FileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func renameIfExists(from, to string) error {
+	if err := os.Rename(from, to); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package report_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+// readLines decodes the JSON lines of a file.
+func readLines(t *testing.T, path string) []map[string]any {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	sink, err := report.NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := report.New(report.WithSinks(sink))
+
+	ErrNotFound := errx.NewSentinel("not found")
+	_ = r.Report(context.Background(), errx.Wrap("load order", errors.New("no rows"), ErrNotFound, errx.Attrs("order_id", 42)))
+	_ = r.Report(context.Background(), errors.New("disk full"))
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := readLines(t, path)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(lines))
+	}
+	serialized := lines[0]["error"].(map[string]any)
+	if serialized["message"] != "load order: no rows" || serialized["sentinels"].([]any)[0] != "not found" {
+		t.Errorf("unexpected error %v", serialized)
+	}
+	if lines[0]["severity"] != "ERROR" || lines[0]["fingerprint"] == "" {
+		t.Errorf("unexpected event %v", lines[0])
+	}
+
+	if err := sink.Write(context.Background(), &report.Event{}); err == nil {
+		t.Error("expected writes after Close to fail")
+	}
+}
+
+func TestFileSink_Appends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	for range 2 {
+		sink, err := report.NewFileSink(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = report.New(report.WithSinks(sink)).Report(context.Background(), errors.New("failed"))
+		_ = sink.Close()
+	}
+	if lines := readLines(t, path); len(lines) != 2 {
+		t.Errorf("expected events to be appended, got %d lines", len(lines))
+	}
+}
+
+func TestFileSink_Rotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	// Every event exceeds the maximum size, so each one rotates the file
+	sink, err := report.NewFileSink(path, report.WithMaxFileSize(10), report.WithMaxBackups(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := report.New(report.WithSinks(sink))
+	for _, msg := range []string{"first", "second", "third", "fourth"} {
+		if err := r.Report(context.Background(), errors.New(msg)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = r.Close()
+
+	for file, want := range map[string]string{path: "fourth", path + ".1": "third", path + ".2": "second"} {
+		lines := readLines(t, file)
+		if len(lines) != 1 || lines[0]["error"].(map[string]any)["message"] != want {
+			t.Errorf("%s: expected the %s event, got %v", filepath.Base(file), want, lines)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected older backups to be removed")
+	}
+}
+
+func TestFileSink_RotationWithoutBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "errors.jsonl")
+	sink, err := report.NewFileSink(path, report.WithMaxFileSize(10), report.WithMaxBackups(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := report.New(report.WithSinks(sink))
+	_ = r.Report(context.Background(), errors.New("first"))
+	_ = r.Report(context.Background(), errors.New("second"))
+	_ = r.Close()
+
+	if lines := readLines(t, path); len(lines) != 1 {
+		t.Errorf("expected the file to be truncated, got %d lines", len(lines))
+	}
+	if _, err := os.Stat(path + ".1"); !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected no backup")
+	}
+}
+
+func TestNewFileSink_Error(t *testing.T) {
+	if _, err := report.NewFileSink(filepath.Join(t.TempDir(), "missing", "errors.jsonl")); err == nil {
+		t.Error("expected error for a missing directory")
+	}
+}
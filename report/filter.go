@@ -0,0 +1,97 @@
+package report
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-extras/errx"
+)
+
+// IgnoreSentinels returns a Filter rejecting errors that match one of the given
+// sentinels via errors.Is. Sentinel hierarchies are respected: ignoring a parent
+// also ignores errors classified with its children.
+// It is an early filter, see EarlyFilterFunc.
+//
+// Example:
+//
+//	// Expected conditions aren't worth reporting
+//	report.IgnoreSentinels(ErrNotFound, ErrValidation)
+func IgnoreSentinels(sentinels ...errx.Classified) Filter {
+	return EarlyFilterFunc(func(_ context.Context, e *Event) bool {
+		for _, s := range sentinels {
+			if errors.Is(e.Err, s) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// MinSeverity returns a Filter rejecting errors whose severity is below level.
+// It is an early filter, see EarlyFilterFunc.
+func MinSeverity(level slog.Level) Filter {
+	return EarlyFilterFunc(func(_ context.Context, e *Event) bool {
+		return e.Severity >= level
+	})
+}
+
+// RateLimit returns a Filter allowing at most n events per fingerprint in each
+// period, so that an error repeating in a loop doesn't flood the sinks. Events
+// without a fingerprint are fingerprinted with errx.Fingerprint.
+// If n is less than 1, every event is allowed.
+//
+// The returned Filter keeps its state, so it should be shared by the callers
+// that are limited together.
+func RateLimit(n int, per time.Duration) Filter {
+	if n < 1 {
+		return FilterFunc(func(context.Context, *Event) bool { return true })
+	}
+	return &rateLimiter{limit: n, period: per, windows: make(map[string]*rateWindow)}
+}
+
+type rateLimiter struct {
+	limit  int
+	period time.Duration
+
+	mu        sync.Mutex
+	windows   map[string]*rateWindow
+	lastSweep time.Time
+}
+
+// rateWindow counts the events of a fingerprint since start.
+type rateWindow struct {
+	start time.Time
+	count int
+}
+
+func (l *rateLimiter) Allow(_ context.Context, e *Event) bool {
+	fingerprint := e.Fingerprint
+	if fingerprint == "" {
+		fingerprint = errx.Fingerprint(e.Err)
+	}
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop expired windows once per period, so fingerprints seen once don't accumulate
+	if now.Sub(l.lastSweep) >= l.period {
+		for key, w := range l.windows {
+			if now.Sub(w.start) >= l.period {
+				delete(l.windows, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	w := l.windows[fingerprint]
+	if w == nil || now.Sub(w.start) >= l.period {
+		w = &rateWindow{start: now}
+		l.windows[fingerprint] = w
+	}
+	w.count++
+	return w.count <= l.limit
+}
@@ -0,0 +1,102 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+func TestIgnoreSentinels(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	ErrOrderNotFound := errx.NewSentinel("order not found", ErrNotFound)
+	ErrDatabase := errx.NewSentinel("database")
+
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(report.IgnoreSentinels(ErrNotFound)))
+	ctx := context.Background()
+
+	_ = r.Report(ctx, errx.Classify(errors.New("no rows"), ErrOrderNotFound))
+	_ = r.Report(ctx, errx.Classify(errors.New("timeout"), ErrDatabase))
+
+	if len(rec.events) != 1 || !errors.Is(rec.events[0].Err, ErrDatabase) {
+		t.Errorf("expected only the database error to be reported, got %d events", len(rec.events))
+	}
+}
+
+func TestMinSeverity(t *testing.T) {
+	ErrConflict := errx.NewSentinel("conflict", errx.Severity(slog.LevelWarn))
+
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(report.MinSeverity(slog.LevelError)))
+	ctx := context.Background()
+
+	_ = r.Report(ctx, errx.Classify(errors.New("stale"), ErrConflict))
+	_ = r.Report(ctx, errors.New("crashed"))
+
+	if len(rec.events) != 1 || rec.events[0].Error.Message != "crashed" {
+		t.Errorf("expected only the error to be reported, got %d events", len(rec.events))
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(report.RateLimit(2, time.Hour)))
+	ctx := context.Background()
+
+	for range 5 {
+		_ = r.Report(ctx, errors.New("connection refused"))
+	}
+	_ = r.Report(ctx, errors.New("disk full"))
+
+	if len(rec.events) != 3 {
+		t.Fatalf("expected 2 events per fingerprint, got %d", len(rec.events))
+	}
+	if rec.events[2].Error.Message != "disk full" {
+		t.Errorf("expected other fingerprints to be allowed, got %q", rec.events[2].Error.Message)
+	}
+}
+
+func TestRateLimit_Window(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(report.RateLimit(1, 20*time.Millisecond)))
+	ctx := context.Background()
+
+	_ = r.Report(ctx, errors.New("failed"))
+	_ = r.Report(ctx, errors.New("failed"))
+	time.Sleep(30 * time.Millisecond)
+	_ = r.Report(ctx, errors.New("failed"))
+
+	if len(rec.events) != 2 {
+		t.Errorf("expected the limit to reset after the period, got %d events", len(rec.events))
+	}
+}
+
+func TestRateLimit_WithoutFingerprint(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithEnrichers(), report.WithFilters(report.RateLimit(1, time.Hour)))
+	ctx := context.Background()
+
+	_ = r.Report(ctx, errors.New("failed"))
+	_ = r.Report(ctx, errors.New("failed"))
+	_ = r.Report(ctx, errors.New("other"))
+
+	if len(rec.events) != 2 {
+		t.Errorf("expected events to be fingerprinted by the limiter, got %d events", len(rec.events))
+	}
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(report.RateLimit(0, time.Hour)))
+	for range 3 {
+		_ = r.Report(context.Background(), errors.New("failed"))
+	}
+	if len(rec.events) != 3 {
+		t.Errorf("expected every event to be allowed, got %d", len(rec.events))
+	}
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// PrettySink writes events in a human-readable form, for local development.
+// A PrettySink is safe for concurrent use.
+type PrettySink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewPrettySink returns a PrettySink writing to w.
+func NewPrettySink(w io.Writer) *PrettySink {
+	return &PrettySink{w: w}
+}
+
+// Stderr returns a PrettySink writing to standard error.
+func Stderr() *PrettySink {
+	return NewPrettySink(os.Stderr)
+}
+
+// Write prints e as a header line with the time, severity and message, followed by
+// the fingerprint, sentinels, display text, attributes, trace IDs and stack trace:
+//
+//	2026-01-02T15:04:05Z ERROR load order: no rows
+//	    fingerprint: 3f2a...
+//	    sentinels:   not found
+//	    attributes:  order_id=42
+//	    at main.loadOrder (/src/app/orders.go:42)
+func (s *PrettySink) Write(_ context.Context, e *Event) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s %s\n", e.Time.Format(time.RFC3339), e.Severity, e.Error.Message)
+
+	field := func(name, value string) {
+		if value != "" {
+			fmt.Fprintf(&b, "    %-12s %s\n", name+":", value)
+		}
+	}
+	field("fingerprint", e.Fingerprint)
+	field("sentinels", strings.Join(e.Error.Sentinels, ", "))
+	field("display", e.Error.DisplayText)
+	field("attributes", formatAttributes(e.Error))
+	if e.TraceID != "" {
+		field("trace", e.TraceID+" span "+e.SpanID)
+	}
+	field("host", e.Host)
+	for _, frame := range stackTrace(e.Error) {
+		fmt.Fprintf(&b, "    at %s (%s:%d)\n", frame.Function, frame.File, frame.Line)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(b.Bytes()); err != nil {
+		return fmt.Errorf("report: write event: %w", err)
+	}
+	return nil
+}
+
+// formatAttributes returns the attributes of a serialized error as key=value pairs.
+func formatAttributes(se *errxjson.SerializedError) string {
+	pairs := make([]string, 0, len(se.Attributes))
+	for _, attr := range se.Attributes {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", attr.Key, attr.Value))
+	}
+	return strings.Join(pairs, " ")
+}
+
+// stackTrace returns the first stack trace in a serialized error chain.
+func stackTrace(se *errxjson.SerializedError) []errxjson.SerializedFrame {
+	for se != nil {
+		if len(se.StackTrace) > 0 {
+			return se.StackTrace
+		}
+		se = se.Cause
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+func TestPrettySink(t *testing.T) {
+	var buf bytes.Buffer
+	ErrPaymentDeclined := errx.NewDisplayable("Payment failed")
+	ErrPayment := errx.NewSentinel("payment")
+
+	e := &report.Event{
+		Time:        time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC),
+		Fingerprint: "3f2a",
+		TraceID:     testTraceID,
+		SpanID:      testSpanID,
+		Host:        "web-1",
+	}
+	err := errx.Wrap("charge order", errors.New("issuer response 51"), ErrPaymentDeclined, ErrPayment, errx.Attrs("order_id", 42))
+	r := report.New(report.WithSinks(report.NewPrettySink(&buf)), report.WithEnrichers(report.EnricherFunc(func(_ context.Context, ev *report.Event) {
+		ev.Time, ev.Fingerprint, ev.TraceID, ev.SpanID, ev.Host = e.Time, e.Fingerprint, e.TraceID, e.SpanID, e.Host
+	})))
+	if err := r.Report(context.Background(), err); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "2026-01-02T15:04:05Z ERROR charge order: issuer response 51\n" +
+		"    fingerprint: 3f2a\n" +
+		"    sentinels:   payment\n" +
+		"    display:     Payment failed\n" +
+		"    attributes:  order_id=42\n" +
+		"    trace:       " + testTraceID + " span " + testSpanID + "\n" +
+		"    host:        web-1\n"
+	if buf.String() != want {
+		t.Errorf("unexpected output:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestPrettySink_Minimal(t *testing.T) {
+	var buf bytes.Buffer
+	r := report.New(report.WithSinks(report.NewPrettySink(&buf)), report.WithEnrichers())
+	if err := r.Report(context.Background(), errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); len(lines) != 1 || !strings.HasSuffix(lines[0], " ERROR failed") {
+		t.Errorf("expected a single header line, got %q", buf.String())
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !errx_notrace
+
+package report_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx/report"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestPrettySink_StackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	r := report.New(report.WithSinks(report.NewPrettySink(&buf)), report.WithEnrichers())
+	if err := r.Report(context.Background(), stacktrace.Wrap("failed", errors.New("cause"))); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "    at github.com/go-extras/errx/report_test.TestPrettySink_StackTrace (") {
+		t.Errorf("expected the stack trace, got:\n%s", buf.String())
+	}
+}
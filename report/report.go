@@ -0,0 +1,284 @@
+// Package report provides a pluggable pipeline for reporting errx errors to local
+// sinks and error trackers, keeping their sentinels, attributes and stack traces.
+//
+// A Reporter turns each reported error into an Event, enriches it (fingerprint,
+// build info, host, trace IDs), applies filters (ignore by sentinel, rate limits)
+// and writes it to every sink. Filters that only need the error itself run before
+// the error is serialized and enriched, so rejected errors cost little.
+//
+// # Basic Usage
+//
+//	file, err := report.NewFileSink("/var/log/app/errors.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	reporter := report.New(
+//	    report.WithSinks(file, report.Stderr()),
+//	    report.WithFilters(
+//	        report.IgnoreSentinels(ErrNotFound),
+//	        report.RateLimit(10, time.Minute),
+//	    ),
+//	)
+//	defer reporter.Close()
+//
+//	reporter.Report(ctx, err)
+//
+// # Custom Sinks
+//
+// Anything implementing Sink can receive events, such as an adapter for an error
+// tracking service:
+//
+//	report.SinkFunc(func(ctx context.Context, e *report.Event) error {
+//	    return tracker.Capture(ctx, e.Fingerprint, e.Error)
+//	})
+package report
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/go-extras/errx"
+	errxjson "github.com/go-extras/errx/json"
+)
+
+// Event is a reported error with the data collected by the Reporter and its enrichers.
+// Events are serialized as JSON by the built-in sinks.
+type Event struct {
+	// Time is when the error was reported
+	Time time.Time `json:"time"`
+
+	// Severity is the severity of the error, as returned by errx.SeverityOf
+	Severity slog.Level `json:"severity"`
+
+	// Fingerprint groups occurrences of the same error, see errx.Fingerprint
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// TraceID and SpanID identify the trace the error occurred in
+	TraceID string `json:"trace_id,omitempty"`
+	SpanID  string `json:"span_id,omitempty"`
+
+	// Host is the name of the host reporting the error
+	Host string `json:"host,omitempty"`
+
+	// Build describes the binary reporting the error
+	Build *Build `json:"build,omitempty"`
+
+	// Tags contains additional values set by custom enrichers
+	Tags map[string]string `json:"tags,omitempty"`
+
+	// Error is the serialized error, with its sentinels, attributes and stack trace
+	Error *errxjson.SerializedError `json:"error"`
+
+	// Err is the reported error
+	Err error `json:"-"`
+}
+
+// Enricher adds data to events before the filters needing it run and the events are written.
+type Enricher interface {
+	Enrich(ctx context.Context, e *Event)
+}
+
+// EnricherFunc adapts a function to the Enricher interface.
+type EnricherFunc func(ctx context.Context, e *Event)
+
+// Enrich calls f(ctx, e).
+func (f EnricherFunc) Enrich(ctx context.Context, e *Event) {
+	f(ctx, e)
+}
+
+// Filter decides whether an event is written to the sinks.
+type Filter interface {
+	Allow(ctx context.Context, e *Event) bool
+}
+
+// FilterFunc adapts a function to the Filter interface.
+type FilterFunc func(ctx context.Context, e *Event) bool
+
+// Allow calls f(ctx, e).
+func (f FilterFunc) Allow(ctx context.Context, e *Event) bool {
+	return f(ctx, e)
+}
+
+// EarlyFilterFunc adapts a function using only the Time, Severity and Err fields of
+// an Event to the Filter interface. The Reporter runs such filters before the error
+// is serialized and enriched, so the other fields of the event are not set yet.
+// IgnoreSentinels and MinSeverity are early filters.
+//
+// Example:
+//
+//	report.EarlyFilterFunc(func(_ context.Context, e *report.Event) bool {
+//	    return !errors.Is(e.Err, context.Canceled)
+//	})
+type EarlyFilterFunc func(ctx context.Context, e *Event) bool
+
+// Allow calls f(ctx, e).
+func (f EarlyFilterFunc) Allow(ctx context.Context, e *Event) bool {
+	return f(ctx, e)
+}
+
+// early marks f as runnable before serialization and enrichment.
+func (EarlyFilterFunc) early() {}
+
+// earlyFilter is implemented by the filters the Reporter runs before serialization.
+type earlyFilter interface {
+	Filter
+	early()
+}
+
+// Sink receives the events of a Reporter. Sinks must be safe for concurrent use.
+// Sinks that also implement io.Closer are closed by Reporter.Close.
+type Sink interface {
+	Write(ctx context.Context, e *Event) error
+}
+
+// SinkFunc adapts a function to the Sink interface.
+type SinkFunc func(ctx context.Context, e *Event) error
+
+// Write calls f(ctx, e).
+func (f SinkFunc) Write(ctx context.Context, e *Event) error {
+	return f(ctx, e)
+}
+
+// config holds the configuration of a Reporter.
+type config struct {
+	enrichers   []Enricher
+	filters     []Filter
+	sinks       []Sink
+	jsonOptions []errxjson.Option
+}
+
+// defaultConfig returns the default configuration.
+func defaultConfig() *config {
+	return &config{
+		enrichers: DefaultEnrichers(),
+	}
+}
+
+// Option configures a Reporter.
+type Option func(*config)
+
+// WithSinks adds sinks receiving the reported events.
+func WithSinks(sinks ...Sink) Option {
+	return func(c *config) {
+		c.sinks = append(c.sinks, sinks...)
+	}
+}
+
+// WithEnrichers replaces the default enrichers (see DefaultEnrichers).
+// Enrichers run in order, after early filters and before the other filters.
+func WithEnrichers(enrichers ...Enricher) Option {
+	return func(c *config) {
+		c.enrichers = enrichers
+	}
+}
+
+// WithFilters adds filters. An event is written only if all filters allow it.
+// Early filters (see EarlyFilterFunc) run first, before the error is serialized and
+// enriched, followed by the other filters. Within each phase filters run in order,
+// and filtering stops at the first one rejecting the event, so that rate limits
+// only count events that passed the other filters.
+func WithFilters(filters ...Filter) Option {
+	return func(c *config) {
+		c.filters = append(c.filters, filters...)
+	}
+}
+
+// WithJSONOptions sets the options used to serialize errors into Event.Error.
+func WithJSONOptions(opts ...errxjson.Option) Option {
+	return func(c *config) {
+		c.jsonOptions = opts
+	}
+}
+
+// Reporter enriches, filters and writes errors to sinks.
+// A Reporter is safe for concurrent use.
+type Reporter struct {
+	cfg *config
+
+	// earlyFilters and lateFilters are cfg.filters split by phase
+	earlyFilters []Filter
+	lateFilters  []Filter
+}
+
+// New returns a Reporter configured with the given options.
+func New(opts ...Option) *Reporter {
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	r := &Reporter{cfg: cfg}
+	for _, filter := range cfg.filters {
+		if _, ok := filter.(earlyFilter); ok {
+			r.earlyFilters = append(r.earlyFilters, filter)
+		} else {
+			r.lateFilters = append(r.lateFilters, filter)
+		}
+	}
+	return r
+}
+
+// Report turns err into an Event and writes it to all sinks, unless a filter rejects it.
+// The errors returned by sinks are joined; a failing sink doesn't keep the event from
+// the others. Reporting a nil error does nothing.
+//
+// Example:
+//
+//	if err := handle(ctx, req); err != nil {
+//	    reporter.Report(ctx, err)
+//	}
+func (r *Reporter) Report(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &Event{
+		Time:     time.Now(),
+		Severity: errx.SeverityOf(err),
+		Err:      err,
+	}
+	if !allow(ctx, e, r.earlyFilters) {
+		return nil
+	}
+
+	e.Error = errxjson.ToSerializedError(err, r.cfg.jsonOptions...)
+	for _, enricher := range r.cfg.enrichers {
+		enricher.Enrich(ctx, e)
+	}
+	if !allow(ctx, e, r.lateFilters) {
+		return nil
+	}
+
+	var errs []error
+	for _, sink := range r.cfg.sinks {
+		if err := sink.Write(ctx, e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// allow reports whether all filters allow e, stopping at the first rejection.
+func allow(ctx context.Context, e *Event, filters []Filter) bool {
+	for _, filter := range filters {
+		if !filter.Allow(ctx, e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Close closes the sinks implementing io.Closer and returns their errors joined.
+func (r *Reporter) Close() error {
+	var errs []error
+	for _, sink := range r.cfg.sinks {
+		if c, ok := sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
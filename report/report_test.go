@@ -0,0 +1,183 @@
+package report_test
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+// recorder is a Sink recording the events it receives.
+type recorder struct {
+	mu     sync.Mutex
+	events []*report.Event
+	closed bool
+}
+
+func (r *recorder) Write(_ context.Context, e *report.Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, e)
+	return nil
+}
+
+func (r *recorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestReporter_Report(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found", errx.Severity(slog.LevelWarn))
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec))
+
+	err := errx.Wrap("load order", errors.New("no rows"), ErrNotFound, errx.Attrs("order_id", 42))
+	if got := r.Report(context.Background(), err); got != nil {
+		t.Fatalf("Report() error = %v", got)
+	}
+	if len(rec.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(rec.events))
+	}
+
+	e := rec.events[0]
+	if e.Err != err || e.Time.IsZero() || e.Severity != slog.LevelWarn {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if e.Error.Message != "load order: no rows" || len(e.Error.Sentinels) != 1 || len(e.Error.Attributes) != 1 {
+		t.Errorf("unexpected serialized error %+v", e.Error)
+	}
+	if e.Fingerprint != errx.Fingerprint(err) {
+		t.Errorf("expected the default enrichers to set the fingerprint, got %q", e.Fingerprint)
+	}
+}
+
+func TestReporter_Nil(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec))
+	if err := r.Report(context.Background(), nil); err != nil || len(rec.events) != 0 {
+		t.Errorf("expected nil errors not to be reported, got %v and %d events", err, len(rec.events))
+	}
+}
+
+func TestReporter_Filters(t *testing.T) {
+	var calls []string
+	filter := func(name string, allow bool) report.Filter {
+		return report.FilterFunc(func(context.Context, *report.Event) bool {
+			calls = append(calls, name)
+			return allow
+		})
+	}
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithFilters(filter("a", true), filter("b", false), filter("c", true)))
+
+	if err := r.Report(context.Background(), errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 0 {
+		t.Error("expected the event to be filtered")
+	}
+	if len(calls) != 2 || calls[1] != "b" {
+		t.Errorf("expected filters to stop at the first rejection, got %v", calls)
+	}
+}
+
+func TestReporter_EarlyFilters(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	var calls []string
+	enricher := report.EnricherFunc(func(context.Context, *report.Event) {
+		calls = append(calls, "enrich")
+	})
+	late := report.FilterFunc(func(_ context.Context, e *report.Event) bool {
+		calls = append(calls, "late")
+		if e.Error == nil {
+			t.Error("expected late filters to see the serialized error")
+		}
+		return true
+	})
+	early := report.EarlyFilterFunc(func(_ context.Context, e *report.Event) bool {
+		calls = append(calls, "early")
+		if e.Error != nil || e.Err == nil {
+			t.Error("expected early filters to run before serialization")
+		}
+		return true
+	})
+	rec := &recorder{}
+	r := report.New(
+		report.WithSinks(rec),
+		report.WithEnrichers(enricher),
+		report.WithFilters(late, early, report.IgnoreSentinels(ErrNotFound)),
+	)
+
+	if err := r.Report(context.Background(), errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(rec.events))
+	}
+	if want := []string{"early", "enrich", "late"}; !slices.Equal(calls, want) {
+		t.Errorf("calls = %v, want %v", calls, want)
+	}
+
+	calls = nil
+	if err := r.Report(context.Background(), errx.Classify(errors.New("no rows"), ErrNotFound)); err != nil {
+		t.Fatal(err)
+	}
+	if len(rec.events) != 1 {
+		t.Error("expected the event to be filtered")
+	}
+	if want := []string{"early"}; !slices.Equal(calls, want) {
+		t.Errorf("expected rejected errors not to be enriched, calls = %v", calls)
+	}
+}
+
+func TestReporter_SinkErrors(t *testing.T) {
+	errA := errors.New("sink a down")
+	errB := errors.New("sink b down")
+	failing := func(err error) report.Sink {
+		return report.SinkFunc(func(context.Context, *report.Event) error { return err })
+	}
+	rec := &recorder{}
+	r := report.New(report.WithSinks(failing(errA), rec, failing(errB)))
+
+	err := r.Report(context.Background(), errors.New("failed"))
+	if !errors.Is(err, errA) || !errors.Is(err, errB) {
+		t.Errorf("expected joined sink errors, got %v", err)
+	}
+	if len(rec.events) != 1 {
+		t.Error("expected the other sinks to receive the event")
+	}
+}
+
+func TestReporter_WithEnrichers(t *testing.T) {
+	rec := &recorder{}
+	r := report.New(report.WithSinks(rec), report.WithEnrichers(report.Tag("service", "orders")))
+
+	if err := r.Report(context.Background(), errors.New("failed")); err != nil {
+		t.Fatal(err)
+	}
+	e := rec.events[0]
+	if e.Tags["service"] != "orders" {
+		t.Errorf("unexpected tags %v", e.Tags)
+	}
+	if e.Fingerprint != "" || e.Host != "" || e.Build != nil {
+		t.Errorf("expected the default enrichers to be replaced, got %+v", e)
+	}
+}
+
+func TestReporter_Close(t *testing.T) {
+	rec := &recorder{}
+	plain := report.SinkFunc(func(context.Context, *report.Event) error { return nil })
+	r := report.New(report.WithSinks(plain, rec))
+
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !rec.closed {
+		t.Error("expected closable sinks to be closed")
+	}
+}
@@ -0,0 +1,86 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// defaultWebhookTimeout bounds webhook requests unless a client is set with WithHTTPClient.
+const defaultWebhookTimeout = 10 * time.Second
+
+// WebhookOption configures a WebhookSink.
+type WebhookOption func(*WebhookSink)
+
+// WithHTTPClient sets the client used to send events. The default is a client
+// with a 10 second timeout.
+func WithHTTPClient(client *http.Client) WebhookOption {
+	return func(s *WebhookSink) {
+		s.client = client
+	}
+}
+
+// WithHeader sets a header sent with every request, such as an authorization token.
+func WithHeader(key, value string) WebhookOption {
+	return func(s *WebhookSink) {
+		s.header.Set(key, value)
+	}
+}
+
+// WebhookSink posts each event as JSON to an HTTP endpoint.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+	header http.Header
+}
+
+// NewWebhookSink returns a WebhookSink posting events to url.
+//
+// Example:
+//
+//	report.NewWebhookSink("https://alerts.example.com/hooks/errors",
+//	    report.WithHeader("Authorization", "Bearer "+token))
+func NewWebhookSink(url string, opts ...WebhookOption) *WebhookSink {
+	s := &WebhookSink{
+		url:    url,
+		client: &http.Client{Timeout: defaultWebhookTimeout},
+		header: make(http.Header),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write posts e as JSON with the request context ctx. Responses with a status
+// outside the 2xx range are reported as errors.
+func (s *WebhookSink) Write(ctx context.Context, e *Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("report: encode event: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("report: webhook request: %w", err)
+	}
+	for key, values := range s.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("report: webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("report: webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package report_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/report"
+)
+
+func TestWebhookSink(t *testing.T) {
+	var received map[string]any
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		header = r.Header
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &received); err != nil {
+			t.Errorf("invalid JSON body %q: %v", body, err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sink := report.NewWebhookSink(server.URL, report.WithHeader("Authorization", "Bearer secret"))
+	r := report.New(report.WithSinks(sink))
+
+	ErrNotFound := errx.NewSentinel("not found")
+	err := errx.Wrap("load order", errors.New("no rows"), ErrNotFound, errx.Attrs("order_id", 42))
+	if err := r.Report(context.Background(), err); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if header.Get("Content-Type") != "application/json" || header.Get("Authorization") != "Bearer secret" {
+		t.Errorf("unexpected headers %v", header)
+	}
+	serialized := received["error"].(map[string]any)
+	if serialized["sentinels"].([]any)[0] != "not found" {
+		t.Errorf("expected sentinels to be sent, got %v", serialized)
+	}
+	attr := serialized["attributes"].([]any)[0].(map[string]any)
+	if attr["key"] != "order_id" || attr["value"] != float64(42) {
+		t.Errorf("expected attributes to be sent, got %v", attr)
+	}
+	if received["fingerprint"] != errx.Fingerprint(err) {
+		t.Errorf("unexpected fingerprint %v", received["fingerprint"])
+	}
+}
+
+func TestWebhookSink_Status(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	r := report.New(report.WithSinks(report.NewWebhookSink(server.URL, report.WithHTTPClient(server.Client()))))
+	if err := r.Report(context.Background(), errors.New("failed")); err == nil {
+		t.Error("expected error for a failed delivery")
+	}
+}
+
+func TestWebhookSink_Canceled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("expected no request with a canceled context")
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r := report.New(report.WithSinks(report.NewWebhookSink(server.URL)))
+	if err := r.Report(ctx, errors.New("failed")); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
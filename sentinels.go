@@ -0,0 +1,99 @@
+package errx
+
+import (
+	"errors"
+
+	"github.com/go-extras/errx/internal/errptr"
+)
+
+// Sentinels returns the classification sentinels attached anywhere in err's chain,
+// outermost first, without duplicates. Only the sentinels that were attached are
+// returned, not their parents. Errors in the chain that are sentinels themselves,
+// such as a sentinel returned directly or wrapped with fmt.Errorf, are included.
+//
+// Classifications wrapping a sentinel, such as those created by compat.MarkTimeout,
+// are returned as attached, so errors.Is matches them. Displayable, attributed and
+// other classifications are not sentinels.
+//
+// Returns nil if the error is nil or carries no sentinels.
+//
+// Example:
+//
+//	err := errx.Wrap("load user", errx.Classify(cause, ErrNotFound), ErrDatabase)
+//	errx.Sentinels(err) // [ErrDatabase ErrNotFound]
+func Sentinels(err error) []Classified {
+	var result []Classified
+	walkClassified(err, func(cls Classified) {
+		if isSentinel(cls) {
+			result = append(result, cls)
+		}
+	})
+	return result
+}
+
+// walkClassified visits each Classified error in err's chain once, breadth-first and
+// outermost first: the errors of the chain itself and the classifications attached
+// by carriers. Sentinels are not descended into, since they unwrap to their parents.
+func walkClassified(err error, visit func(Classified)) {
+	if err == nil {
+		return
+	}
+
+	visited := make(map[uintptr]bool)
+	queue := []error{err}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		if current == nil {
+			continue
+		}
+		ptr := errptr.Get(current)
+		if visited[ptr] {
+			continue
+		}
+		visited[ptr] = true
+
+		if cls, ok := current.(Classified); ok {
+			visit(cls)
+			if isSentinel(cls) {
+				continue
+			}
+		}
+
+		if c, ok := current.(interface{ classificationList() []Classified }); ok {
+			for _, cls := range c.classificationList() {
+				queue = append(queue, cls)
+			}
+		}
+
+		type unwrapper interface {
+			Unwrap() []error
+		}
+		if u, ok := current.(unwrapper); ok {
+			queue = append(queue, u.Unwrap()...)
+		} else if next := errors.Unwrap(current); next != nil {
+			queue = append(queue, next)
+		}
+	}
+}
+
+// isSentinel reports whether cls is a sentinel created by NewSentinel or wraps one.
+// Displayable errors embed a sentinel but are not sentinels themselves.
+func isSentinel(cls Classified) bool {
+	_, ok := asSentinel(cls)
+	return ok
+}
+
+// asSentinel returns the sentinel cls is or wraps.
+func asSentinel(cls Classified) (*sentinel, bool) {
+	if _, ok := cls.(*displayable); ok {
+		return nil, false
+	}
+	var s *sentinel
+	if !errors.As(cls, &s) {
+		return nil, false
+	}
+	return s, true
+}
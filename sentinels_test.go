@@ -0,0 +1,79 @@
+package errx_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-extras/errx"
+)
+
+func sentinelTexts(sentinels []errx.Classified) []string {
+	texts := make([]string, len(sentinels))
+	for i, s := range sentinels {
+		texts[i] = s.Error()
+	}
+	return texts
+}
+
+func TestSentinels(t *testing.T) {
+	errDatabase := errx.NewSentinel("database")
+	errTimeout := errx.NewSentinel("timeout", errDatabase)
+	errNotFound := errx.NewSentinel("not found")
+	errRetryable := errx.NewSentinel("retryable")
+
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{"nil", nil, nil},
+		{"standard error", errors.New("plain"), nil},
+		{"sentinel itself", errNotFound, []string{"not found"}},
+		{"wrapped sentinel", fmt.Errorf("lookup: %w", errTimeout), []string{"timeout"}},
+		{
+			"classified",
+			errx.Classify(errors.New("boom"), errTimeout, errx.NewDisplayable("Try again"), errx.Attrs("k", "v")),
+			[]string{"timeout"},
+		},
+		{
+			"nested outermost first",
+			errx.Wrap("outer", errx.Wrap("inner", errors.New("boom"), errNotFound), errRetryable),
+			[]string{"retryable", "not found"},
+		},
+		{
+			"duplicates",
+			errx.Classify(errx.Classify(errors.New("boom"), errNotFound), errNotFound),
+			[]string{"not found"},
+		},
+		{
+			"multi-error",
+			errors.Join(errx.Classify(errors.New("a"), errNotFound), errx.Classify(errors.New("b"), errRetryable)),
+			[]string{"not found", "retryable"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := errx.Sentinels(tt.err)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Sentinels() = %v, want %v", sentinelTexts(got), tt.want)
+			}
+			for i, s := range got {
+				if s.Error() != tt.want[i] {
+					t.Errorf("Sentinels()[%d] = %q, want %q", i, s.Error(), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSentinels_Identity(t *testing.T) {
+	errNotFound := errx.NewSentinel("not found")
+	err := errx.Wrap("lookup", errors.New("boom"), errNotFound)
+
+	got := errx.Sentinels(err)
+	if len(got) != 1 || got[0] != errNotFound {
+		t.Errorf("Sentinels() = %v, want the attached sentinel", got)
+	}
+}
@@ -0,0 +1,116 @@
+package errx
+
+import (
+	"log/slog"
+)
+
+// Ensure severity implements Classified interface
+var _ Classified = (*severity)(nil)
+
+// severity is a classification carrying a log level.
+type severity struct {
+	level slog.Level
+}
+
+func (s *severity) Error() string {
+	return "severity " + s.level.String()
+}
+
+// Level returns the log level of the severity.
+func (s *severity) Level() slog.Level {
+	return s.level
+}
+
+// IsClassified implements the Classified interface marker method.
+// It always returns true to identify this as a Classified error.
+func (*severity) IsClassified() bool {
+	return true
+}
+
+// Severity returns a classification assigning a severity, expressed as a slog level,
+// to the errors it is attached to.
+//
+// Attach it with Wrap or Classify to set the severity of a single error, or pass it
+// as a parent to NewSentinel to give a sentinel a default severity. Child sentinels
+// inherit the severity of their parents unless they declare their own:
+//
+//	var (
+//	    ErrNotFound        = errx.NewSentinel("not found", errx.Severity(slog.LevelInfo))
+//	    ErrDatabase        = errx.NewSentinel("database", errx.Severity(slog.LevelWarn))
+//	    ErrDatabaseCorrupt = errx.NewSentinel("database corrupt", ErrDatabase, errx.Severity(slog.LevelError))
+//	    ErrDatabaseTimeout = errx.NewSentinel("database timeout", ErrDatabase) // inherits Warn
+//	)
+func Severity(level slog.Level) Classified {
+	return &severity{level: level}
+}
+
+// SeverityOf returns the highest severity in err's chain, considering severities
+// attached with Severity and the default severities of attached sentinels.
+// It returns slog.LevelError if the chain carries no severity.
+//
+// The result can be passed directly to slog:
+//
+//	logger.Log(ctx, errx.SeverityOf(err), "request failed", "error", err)
+func SeverityOf(err error) slog.Level {
+	return SeverityOfDefault(err, slog.LevelError)
+}
+
+// SeverityOfDefault is like SeverityOf, but returns def if the chain carries no severity.
+func SeverityOfDefault(err error, def slog.Level) slog.Level {
+	var (
+		highest slog.Level
+		found   bool
+	)
+	walkClassified(err, func(cls Classified) {
+		if level, ok := classificationSeverity(cls, 0); ok && (!found || level > highest) {
+			highest, found = level, true
+		}
+	})
+	if !found {
+		return def
+	}
+	return highest
+}
+
+// classificationSeverity returns the severity of a Severity classification or the
+// default severity of a sentinel.
+func classificationSeverity(cls Classified, depth int) (slog.Level, bool) {
+	if s, ok := cls.(*severity); ok {
+		return s.level, true
+	}
+	if depth >= maxHierarchyDepth {
+		return 0, false
+	}
+	s, ok := asSentinel(cls)
+	if !ok {
+		return 0, false
+	}
+
+	// Severities declared by the sentinel itself override inherited ones
+	if level, ok := highestOf(s.parents, func(parent Classified) (slog.Level, bool) {
+		sev, ok := parent.(*severity)
+		if !ok {
+			return 0, false
+		}
+		return sev.level, true
+	}); ok {
+		return level, true
+	}
+	return highestOf(s.parents, func(parent Classified) (slog.Level, bool) {
+		return classificationSeverity(parent, depth+1)
+	})
+}
+
+// highestOf returns the highest level reported by levelOf for the classifications.
+func highestOf(classifications []Classified, levelOf func(Classified) (slog.Level, bool)) (slog.Level, bool) {
+	var (
+		highest slog.Level
+		found   bool
+	)
+	for _, cls := range classifications {
+		if level, ok := levelOf(cls); ok && (!found || level > highest) {
+			highest, found = level, true
+		}
+	}
+	return highest, found
+}
@@ -0,0 +1,80 @@
+package errx_test
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/go-extras/errx"
+)
+
+var (
+	errSevNotFound = errx.NewSentinel("not found", errx.Severity(slog.LevelInfo))
+	errSevDatabase = errx.NewSentinel("database", errx.Severity(slog.LevelWarn))
+	errSevTimeout  = errx.NewSentinel("database timeout", errSevDatabase)
+	errSevCorrupt  = errx.NewSentinel("database corrupt", errSevDatabase, errx.Severity(slog.LevelError))
+	errSevQuiet    = errx.NewSentinel("quiet", errSevDatabase, errx.Severity(slog.LevelDebug))
+	errSevPlain    = errx.NewSentinel("plain")
+	errSevMixed    = errx.NewSentinel("mixed", errSevNotFound, errSevCorrupt)
+)
+
+func TestSeverityOf(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name string
+		err  error
+		want slog.Level
+	}{
+		{"no severity", base, slog.LevelError},
+		{"nil", nil, slog.LevelError},
+		{"sentinel default", errx.Classify(base, errSevNotFound), slog.LevelInfo},
+		{"sentinel returned directly", errSevNotFound, slog.LevelInfo},
+		{"wrapped sentinel", fmt.Errorf("lookup: %w", errSevNotFound), slog.LevelInfo},
+		{"inherited from parent", errx.Classify(base, errSevTimeout), slog.LevelWarn},
+		{"child overrides parent upwards", errx.Classify(base, errSevCorrupt), slog.LevelError},
+		{"child overrides parent downwards", errx.Classify(base, errSevQuiet), slog.LevelDebug},
+		{"highest of several parents", errx.Classify(base, errSevMixed), slog.LevelError},
+		{"sentinel without severity", errx.Classify(base, errSevPlain), slog.LevelError},
+		{"explicit severity", errx.Classify(base, errx.Severity(slog.LevelWarn)), slog.LevelWarn},
+		{"highest in chain", errx.Wrap("outer", errx.Classify(base, errSevNotFound), errSevTimeout), slog.LevelWarn},
+		{"explicit raises sentinel", errx.Classify(base, errSevNotFound, errx.Severity(slog.LevelError+4)), slog.LevelError + 4},
+		{
+			"multi-error",
+			errors.Join(errx.Classify(base, errSevNotFound), errx.Classify(base, errSevTimeout)),
+			slog.LevelWarn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errx.SeverityOf(tt.err); got != tt.want {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeverityOfDefault(t *testing.T) {
+	if got := errx.SeverityOfDefault(errors.New("boom"), slog.LevelWarn); got != slog.LevelWarn {
+		t.Errorf("SeverityOfDefault() = %v, want %v", got, slog.LevelWarn)
+	}
+	if got := errx.SeverityOfDefault(errx.Classify(errors.New("boom"), errSevNotFound), slog.LevelWarn); got != slog.LevelInfo {
+		t.Errorf("SeverityOfDefault() = %v, want %v", got, slog.LevelInfo)
+	}
+}
+
+func TestSeverity_DoesNotAffectSentinels(t *testing.T) {
+	err := errx.Classify(errors.New("boom"), errSevCorrupt, errx.Severity(slog.LevelWarn))
+
+	if err.Error() != "boom" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "boom")
+	}
+	if !errors.Is(err, errSevDatabase) {
+		t.Error("errors.Is(err, parent) = false, want true")
+	}
+	if got := errx.Sentinels(err); len(got) != 1 || got[0] != errSevCorrupt {
+		t.Errorf("Sentinels() = %v, want only the sentinel", got)
+	}
+}
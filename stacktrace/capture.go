@@ -0,0 +1,39 @@
+//go:build !errx_notrace
+
+package stacktrace
+
+import (
+	"runtime"
+
+	"github.com/go-extras/errx"
+)
+
+// Enabled reports whether stack trace capture is compiled in.
+// It is false when building with the errx_notrace tag.
+const Enabled = true
+
+// captureStack captures the current stack trace with the specified skip count.
+// skip indicates how many stack frames to skip (0 = captureStack itself).
+// ancestor is the spawn trace of the capturing goroutine, if known (see Go).
+func captureStack(skip int, ancestor *traced) *traced {
+	const maxDepth = 32 // Reasonable default depth limit
+	pcs := make([]uintptr, maxDepth)
+	n := runtime.Callers(skip+1, pcs) // +1 to skip captureStack itself
+	return &traced{pcs: pcs[:n], ancestor: ancestor}
+}
+
+// captureCaller captures a single program counter with the specified skip count,
+// using the same convention as captureStack. It returns 0 if no frame is available.
+func captureCaller(skip int) uintptr {
+	var pcs [1]uintptr
+	if runtime.Callers(skip+1, pcs[:]) == 0 {
+		return 0
+	}
+	return pcs[0]
+}
+
+// shouldCapture reports whether the current Policy allows capturing a trace
+// for the given classifications.
+func shouldCapture(classifications []errx.Classified) bool {
+	return currentPolicy().ShouldCapture(classifications)
+}
@@ -0,0 +1,31 @@
+//go:build errx_notrace
+
+package stacktrace
+
+import (
+	"github.com/go-extras/errx"
+)
+
+// Enabled reports whether stack trace capture is compiled in.
+// It is false when building with the errx_notrace tag.
+const Enabled = false
+
+// emptyTrace is returned by captureStack when capture is compiled out.
+// It carries no program counters, so Extract reports no trace for it.
+var emptyTrace = &traced{}
+
+// captureStack is a no-op when built with the errx_notrace tag.
+func captureStack(int, *traced) *traced {
+	return emptyTrace
+}
+
+// captureCaller is a no-op when built with the errx_notrace tag.
+func captureCaller(int) uintptr {
+	return 0
+}
+
+// shouldCapture always reports false when built with the errx_notrace tag,
+// regardless of the configured Policy.
+func shouldCapture([]errx.Classified) bool {
+	return false
+}
@@ -1,3 +1,5 @@
+//go:build !errx_notrace
+
 package stacktrace_test
 
 import (
@@ -143,7 +145,7 @@ func ExampleExtract() {
 
 	// Output:
 	// Stack trace (7 frames):
-	//   github.com/go-extras/errx/stacktrace_test.ExampleExtract:129
+	//   github.com/go-extras/errx/stacktrace_test.ExampleExtract:131
 	//   testing.runExample:63
 	//   testing.runExamples:41
 	//   ...
@@ -0,0 +1,36 @@
+package stacktrace
+
+import (
+	"fmt"
+
+	"github.com/go-extras/errx/internal/errchain"
+	"github.com/go-extras/errx/internal/foreignstack"
+)
+
+// foreignPCs returns the program counters of the first stack trace exposed by a
+// non-errx error in err's chain, or nil if there is none.
+//
+// Foreign stacks are recognized structurally, without importing the libraries:
+//   - Callers() []uintptr, as implemented by github.com/go-errors/errors
+//   - StackTrace() T, where T is a slice of an uintptr-based type, as implemented
+//     by github.com/pkg/errors (errors.StackTrace is a []errors.Frame, and Frame is
+//     a program counter)
+func foreignPCs(err error) []uintptr {
+	var pcs []uintptr
+	errchain.Walk(err, func(e error) bool {
+		pcs = foreignstack.PCs(e)
+		return len(pcs) > 0
+	})
+	return pcs
+}
+
+// foreignFormatter returns the first error in err's chain that renders itself with
+// fmt.Formatter, for libraries that only expose their stack through %+v.
+func foreignFormatter(err error) fmt.Formatter {
+	var f fmt.Formatter
+	errchain.Walk(err, func(e error) bool {
+		f, _ = e.(fmt.Formatter)
+		return f != nil
+	})
+	return f
+}
@@ -0,0 +1,159 @@
+//go:build !errx_notrace
+
+package stacktrace_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// pkgFrame and pkgStackTrace mirror the types used by github.com/pkg/errors
+type (
+	pkgFrame      uintptr
+	pkgStackTrace []pkgFrame
+)
+
+// pkgError mimics an error created by github.com/pkg/errors
+type pkgError struct {
+	msg   string
+	stack []uintptr
+}
+
+func newPkgError(msg string) *pkgError {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &pkgError{msg: msg, stack: pcs[:n]}
+}
+
+func (e *pkgError) Error() string { return e.msg }
+
+func (e *pkgError) StackTrace() pkgStackTrace {
+	st := make(pkgStackTrace, len(e.stack))
+	for i, pc := range e.stack {
+		st[i] = pkgFrame(pc)
+	}
+	return st
+}
+
+// goError mimics an error created by github.com/go-errors/errors
+type goError struct {
+	msg   string
+	stack []uintptr
+}
+
+func newGoError(msg string) *goError {
+	pcs := make([]uintptr, 32)
+	n := runtime.Callers(2, pcs)
+	return &goError{msg: msg, stack: pcs[:n]}
+}
+
+func (e *goError) Error() string      { return e.msg }
+func (e *goError) Callers() []uintptr { return e.stack }
+
+// formattedError only exposes its stack through %+v
+type formattedError struct{}
+
+func (formattedError) Error() string { return "formatted" }
+
+func (e formattedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		_, _ = io.WriteString(s, "formatted\nmain.origin\n\t/app/origin.go:7")
+		return
+	}
+	_, _ = io.WriteString(s, e.Error())
+}
+
+// notAStackError has a StackTrace method with an unrelated signature
+type notAStackError struct{}
+
+func (notAStackError) Error() string        { return "not a stack" }
+func (notAStackError) StackTrace() []string { return []string{"frame"} }
+
+//go:noinline
+func createPkgError() error {
+	return newPkgError("pkg failure")
+}
+
+//go:noinline
+func createGoError() error {
+	return newGoError("go-errors failure")
+}
+
+func TestExtract_PkgErrorsStackTrace(t *testing.T) {
+	err := errx.Wrap("context", createPkgError())
+
+	frames := stacktrace.Extract(err)
+	if len(frames) == 0 {
+		t.Fatal("Expected foreign stack trace")
+	}
+	if !strings.HasSuffix(frames[0].Function, "createPkgError") {
+		t.Errorf("Expected top frame createPkgError, got %s", frames[0].Function)
+	}
+}
+
+func TestExtract_GoErrorsCallers(t *testing.T) {
+	err := fmt.Errorf("context: %w", createGoError())
+
+	frames := stacktrace.Extract(err)
+	if len(frames) == 0 {
+		t.Fatal("Expected foreign stack trace")
+	}
+	if !strings.HasSuffix(frames[0].Function, "createGoError") {
+		t.Errorf("Expected top frame createGoError, got %s", frames[0].Function)
+	}
+}
+
+func TestExtract_ErrxTraceTakesPrecedence(t *testing.T) {
+	err := stacktrace.Wrap("context", createPkgError())
+
+	frames := stacktrace.Extract(err)
+	if len(frames) == 0 {
+		t.Fatal("Expected stack trace")
+	}
+	if !strings.HasSuffix(frames[0].Function, "TestExtract_ErrxTraceTakesPrecedence") {
+		t.Errorf("Expected errx trace to be used, got top frame %s", frames[0].Function)
+	}
+}
+
+func TestExtract_ForeignInMultiError(t *testing.T) {
+	err := errors.Join(errors.New("plain"), createGoError())
+
+	if len(stacktrace.Extract(err)) == 0 {
+		t.Error("Expected foreign stack trace from multi-error branch")
+	}
+}
+
+func TestExtract_UnrelatedStackTraceMethod(t *testing.T) {
+	if frames := stacktrace.Extract(notAStackError{}); frames != nil {
+		t.Errorf("Expected no frames, got %v", frames)
+	}
+	if stacktrace.HasTrace(createPkgError()) {
+		t.Error("Expected HasTrace to only report errx traces")
+	}
+}
+
+func TestFormatter_ForeignTrace(t *testing.T) {
+	err := errx.Wrap("context", createPkgError())
+
+	out := fmt.Sprintf("%+v", stacktrace.Formatter(err))
+	if !strings.Contains(out, "stack trace:") || !strings.Contains(out, "createPkgError") {
+		t.Errorf("Expected foreign frames in output, got:\n%s", out)
+	}
+}
+
+func TestFormatter_ForeignFormatter(t *testing.T) {
+	err := errx.Wrap("context", formattedError{})
+
+	out := fmt.Sprintf("%+v", stacktrace.Formatter(err))
+	want := "context: formatted\nformatted\nmain.origin\n\t/app/origin.go:7"
+	if out != want {
+		t.Errorf("Expected %q, got %q", want, out)
+	}
+}
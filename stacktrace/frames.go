@@ -0,0 +1,53 @@
+package stacktrace
+
+import (
+	"runtime"
+	"sync"
+)
+
+// frameCache is a process-wide cache of resolved frames keyed by program counter.
+// Each entry holds all frames for a single PC, which may be more than one when
+// the call site was inlined. The set of PCs in a binary is finite, so the cache
+// is bounded by the number of distinct call sites that ever captured a trace.
+var frameCache sync.Map // map[uintptr][]Frame
+
+// resolveFrames converts program counters into frames, consulting frameCache
+// for every PC so that traces sharing call sites are only resolved once.
+func resolveFrames(pcs []uintptr) []Frame {
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	result := make([]Frame, 0, len(pcs))
+	for _, pc := range pcs {
+		result = append(result, resolvePC(pc)...)
+	}
+	return result
+}
+
+// resolvePC returns the frames for a single program counter, resolving and
+// caching them on first use.
+func resolvePC(pc uintptr) []Frame {
+	if cached, ok := frameCache.Load(pc); ok {
+		frames, _ := cached.([]Frame)
+		return frames
+	}
+
+	frames := runtime.CallersFrames([]uintptr{pc})
+	var resolved []Frame
+	for {
+		frame, more := frames.Next()
+		resolved = append(resolved, Frame{
+			File:     frame.File,
+			Line:     frame.Line,
+			Function: frame.Function,
+		})
+		if !more {
+			break
+		}
+	}
+
+	actual, _ := frameCache.LoadOrStore(pc, resolved)
+	resolved, _ = actual.([]Frame)
+	return resolved
+}
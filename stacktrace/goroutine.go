@@ -0,0 +1,84 @@
+package stacktrace
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-extras/errx"
+)
+
+// spawnKey is the context key of the spawn trace stored by Go.
+type spawnKey struct{}
+
+// spawnFrom returns the spawn trace carried by ctx, or nil if there is none.
+func spawnFrom(ctx context.Context) *traced {
+	spawn, _ := ctx.Value(spawnKey{}).(*traced)
+	return spawn
+}
+
+// Go starts fn in a new goroutine, recording the caller's stack as the spawn point.
+// fn receives ctx extended with the spawn stack; traces captured with WrapCtx,
+// ClassifyCtx or HereCtx using that context (or one derived from it) are linked to
+// the spawn stack, which can be retrieved with ExtractAncestors. Goroutines started
+// with Go from inside fn using that context are linked in turn, so the full ancestry
+// of nested worker pools is preserved.
+//
+// The spawn stack is carried by the context only, so traces captured without it,
+// and code not using Go, pay no extra cost. The spawn stack is always captured,
+// regardless of the capture Policy. When built with the errx_notrace tag, Go is
+// equivalent to a plain go statement.
+//
+// Example:
+//
+//	stacktrace.Go(ctx, func(ctx context.Context) {
+//	    if err := process(ctx, job); err != nil {
+//	        results <- stacktrace.WrapCtx(ctx, "job failed", err)
+//	    }
+//	})
+func Go(ctx context.Context, fn func(ctx context.Context)) {
+	if !Enabled {
+		go fn(ctx)
+		return
+	}
+	// Capture stack with skip=2 to skip Go() and runtime.Callers
+	spawn := captureStack(2, spawnFrom(ctx))
+	go fn(context.WithValue(ctx, spawnKey{}, spawn))
+}
+
+// HereCtx is like Here, but links the trace to the goroutine spawn stacks carried
+// by ctx (see Go).
+func HereCtx(ctx context.Context) errx.Classified {
+	return captureStack(2, spawnFrom(ctx)) // Skip HereCtx() and runtime.Callers
+}
+
+// WrapCtx is like Wrap, but also attaches the attributes carried by ctx (see
+// errx.WrapCtx) and links the trace to the goroutine spawn stacks carried by ctx
+// (see Go). To attach trace IDs as well, pass HereCtx(ctx) to tracectx.WrapCtx instead.
+//
+// If cause is nil, WrapCtx returns nil.
+func WrapCtx(ctx context.Context, text string, cause error, classifications ...errx.Classified) error {
+	if cause == nil {
+		return nil
+	}
+	if shouldCapture(classifications) {
+		// Capture stack with skip=2 to skip WrapCtx() and runtime.Callers
+		classifications = append(slices.Clip(classifications), captureStack(2, spawnFrom(ctx)))
+	}
+	return errx.WrapCtx(ctx, text, cause, classifications...)
+}
+
+// ClassifyCtx is like Classify, but also attaches the attributes carried by ctx (see
+// errx.ClassifyCtx) and links the trace to the goroutine spawn stacks carried by ctx
+// (see Go).
+//
+// If cause is nil, ClassifyCtx returns nil.
+func ClassifyCtx(ctx context.Context, cause error, classifications ...errx.Classified) error {
+	if cause == nil {
+		return nil
+	}
+	if shouldCapture(classifications) {
+		// Capture stack with skip=2 to skip ClassifyCtx() and runtime.Callers
+		classifications = append(slices.Clip(classifications), captureStack(2, spawnFrom(ctx)))
+	}
+	return errx.ClassifyCtx(ctx, cause, classifications...)
+}
@@ -0,0 +1,191 @@
+//go:build !errx_notrace
+
+package stacktrace_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+	"github.com/go-extras/errx/tracectx"
+)
+
+//go:noinline
+func spawnWorker(ctx context.Context, results chan<- error) {
+	stacktrace.Go(ctx, func(ctx context.Context) {
+		results <- stacktrace.ClassifyCtx(ctx, errors.New("job failed"))
+	})
+}
+
+//go:noinline
+func spawnPool(ctx context.Context, results chan<- error) {
+	stacktrace.Go(ctx, func(ctx context.Context) {
+		spawnWorker(ctx, results)
+	})
+}
+
+func containsFunction(frames []stacktrace.Frame, name string) bool {
+	for _, frame := range frames {
+		if strings.HasSuffix(frame.Function, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGo_LinksSpawnStack(t *testing.T) {
+	results := make(chan error, 1)
+	spawnWorker(context.Background(), results)
+	err := <-results
+
+	if stacktrace.Extract(err) == nil {
+		t.Fatal("Expected stack trace")
+	}
+	ancestors := stacktrace.ExtractAncestors(err)
+	if len(ancestors) != 1 {
+		t.Fatalf("Expected 1 ancestor, got %d", len(ancestors))
+	}
+	if !containsFunction(ancestors[0], "spawnWorker") || !containsFunction(ancestors[0], "TestGo_LinksSpawnStack") {
+		t.Errorf("Expected spawn stack to contain the spawner, got %v", ancestors[0])
+	}
+}
+
+func TestGo_NestedSpawns(t *testing.T) {
+	results := make(chan error, 1)
+	spawnPool(context.Background(), results)
+	err := <-results
+
+	ancestors := stacktrace.ExtractAncestors(err)
+	if len(ancestors) != 2 {
+		t.Fatalf("Expected 2 ancestors, got %d", len(ancestors))
+	}
+	if !containsFunction(ancestors[0], "spawnWorker") {
+		t.Errorf("Expected nearest ancestor to be spawnWorker, got %v", ancestors[0])
+	}
+	if !containsFunction(ancestors[1], "spawnPool") || !containsFunction(ancestors[1], "TestGo_NestedSpawns") {
+		t.Errorf("Expected outer ancestor to be spawnPool, got %v", ancestors[1])
+	}
+}
+
+func TestGo_HereInsideGoroutine(t *testing.T) {
+	results := make(chan error, 1)
+	stacktrace.Go(context.Background(), func(ctx context.Context) {
+		results <- errx.Wrap("context", errors.New("base"), stacktrace.HereCtx(ctx))
+	})
+	err := <-results
+
+	if len(stacktrace.ExtractAncestors(err)) != 1 {
+		t.Error("Expected HereCtx() inside Go to be linked to the spawn stack")
+	}
+}
+
+func TestGo_WithoutContext(t *testing.T) {
+	// Traces captured without the context passed to fn aren't linked
+	results := make(chan error, 1)
+	stacktrace.Go(context.Background(), func(context.Context) {
+		results <- stacktrace.ClassifyNew("job failed")
+	})
+	if ancestors := stacktrace.ExtractAncestors(<-results); ancestors != nil {
+		t.Errorf("Expected no ancestors, got %d", len(ancestors))
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+
+	err := stacktrace.WrapCtx(ctx, "load order", errors.New("no rows"), ErrNotFound)
+	if err.Error() != "load order: no rows" || !errors.Is(err, ErrNotFound) {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if stacktrace.Extract(err) == nil {
+		t.Error("Expected stack trace")
+	}
+	if attrs := errx.ExtractAttrs(err); attrs.String() != "request_id=req-1" {
+		t.Errorf("Expected context attributes, got %v", attrs)
+	}
+	if stacktrace.WrapCtx(ctx, "failed", nil) != nil {
+		t.Error("Expected nil for nil cause")
+	}
+}
+
+func TestClassifyCtx(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+
+	err := stacktrace.ClassifyCtx(ctx, errors.New("timeout"))
+	if err.Error() != "timeout" || stacktrace.Extract(err) == nil || !errx.HasAttrs(err) {
+		t.Errorf("Expected trace and attributes, got %v", err)
+	}
+	if stacktrace.ClassifyCtx(ctx, nil) != nil {
+		t.Error("Expected nil for nil cause")
+	}
+}
+
+func TestWrapAndClassify_DoNotModifyClassifications(t *testing.T) {
+	ctx := context.Background()
+	cause := errors.New("x")
+	funcs := map[string]func(cls ...errx.Classified) error{
+		"Wrap":        func(cls ...errx.Classified) error { return stacktrace.Wrap("failed", cause, cls...) },
+		"Classify":    func(cls ...errx.Classified) error { return stacktrace.Classify(cause, cls...) },
+		"ClassifyNew": func(cls ...errx.Classified) error { return stacktrace.ClassifyNew("failed", cls...) },
+		"WrapCtx":     func(cls ...errx.Classified) error { return stacktrace.WrapCtx(ctx, "failed", cause, cls...) },
+		"ClassifyCtx": func(cls ...errx.Classified) error { return stacktrace.ClassifyCtx(ctx, cause, cls...) },
+	}
+	for name, fn := range funcs {
+		t.Run(name, func(t *testing.T) {
+			classifications := make([]errx.Classified, 1, 2)
+			classifications[0] = errx.NewSentinel("a")
+			if err := fn(classifications...); stacktrace.Extract(err) == nil {
+				t.Fatal("Expected stack trace")
+			}
+
+			if classifications[:2][1] != nil {
+				t.Error("Expected the caller's backing array to be untouched")
+			}
+		})
+	}
+}
+
+func TestWrapCtx_ComposesWithTracectx(t *testing.T) {
+	ctx := errx.ContextWithAttrs(context.Background(), "request_id", "req-1")
+	ctx = tracectx.ContextWithSpan(ctx, tracectx.SpanContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "00f067aa0ba902b7",
+	})
+
+	results := make(chan error, 1)
+	stacktrace.Go(ctx, func(ctx context.Context) {
+		results <- tracectx.WrapCtx(ctx, "job failed", errors.New("timeout"), stacktrace.HereCtx(ctx))
+	})
+	err := <-results
+
+	if len(stacktrace.ExtractAncestors(err)) != 1 {
+		t.Error("Expected the trace to be linked to the spawn stack")
+	}
+	if traceID, _ := tracectx.IDs(err); traceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Expected trace ID, got %q", traceID)
+	}
+	if attrs := errx.ExtractAttrs(err); len(attrs) != 3 || attrs[0].Key != "request_id" {
+		t.Errorf("Expected context and trace attributes, got %v", attrs)
+	}
+}
+
+func TestExtractAncestors_NotSpawned(t *testing.T) {
+	if stacktrace.ExtractAncestors(nil) != nil {
+		t.Error("Expected nil for nil error")
+	}
+	if stacktrace.ExtractAncestors(errors.New("plain")) != nil {
+		t.Error("Expected nil without a stack trace")
+	}
+
+	results := make(chan error, 1)
+	go func() {
+		results <- stacktrace.ClassifyNew("job failed")
+	}()
+	if ancestors := stacktrace.ExtractAncestors(<-results); ancestors != nil {
+		t.Errorf("Expected no ancestors for a plain goroutine, got %d", len(ancestors))
+	}
+}
@@ -0,0 +1,44 @@
+//go:build errx_notrace
+
+package stacktrace_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+func TestNoTrace_CaptureCompiledOut(t *testing.T) {
+	if stacktrace.Enabled {
+		t.Fatal("Expected Enabled to be false with errx_notrace")
+	}
+
+	ErrNotFound := errx.NewSentinel("not found")
+	err := stacktrace.Wrap("context", errors.New("base"), ErrNotFound)
+	if stacktrace.HasTrace(err) {
+		t.Error("Expected Wrap not to attach a trace")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("Expected classification to be preserved")
+	}
+
+	err = errx.Wrap("context", errors.New("base"), stacktrace.Here())
+	if frames := stacktrace.Extract(err); frames != nil {
+		t.Errorf("Expected no frames, got %d", len(frames))
+	}
+	if err.Error() != "context: base" {
+		t.Errorf("Expected message to be unchanged, got %q", err.Error())
+	}
+}
+
+func TestNoTrace_PropagateCompiledOut(t *testing.T) {
+	base := errors.New("base")
+	if err := stacktrace.Propagate(base); err != base {
+		t.Error("Expected Propagate to return the error unchanged")
+	}
+	if frames := stacktrace.ReturnTrace(stacktrace.Propagate(base)); frames != nil {
+		t.Errorf("Expected no steps, got %d", len(frames))
+	}
+}
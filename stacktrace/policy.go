@@ -0,0 +1,131 @@
+package stacktrace
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/go-extras/errx"
+)
+
+// Policy decides whether Wrap, Classify and ClassifyNew capture a stack trace.
+// ShouldCapture receives the classifications passed to the call (without the trace)
+// and must be safe for concurrent use.
+//
+// Policies only affect automatic capture; Here always captures a trace.
+type Policy interface {
+	ShouldCapture(classifications []errx.Classified) bool
+}
+
+// PolicyFunc adapts an ordinary function to the Policy interface.
+type PolicyFunc func(classifications []errx.Classified) bool
+
+// ShouldCapture calls f(classifications).
+func (f PolicyFunc) ShouldCapture(classifications []errx.Classified) bool {
+	return f(classifications)
+}
+
+// policyHolder wraps a Policy so it can be stored in an atomic.Pointer.
+type policyHolder struct {
+	policy Policy
+}
+
+var currentHolder atomic.Pointer[policyHolder]
+
+// SetPolicy sets the capture policy used by Wrap, Classify and ClassifyNew.
+// It is safe to call at any time, including concurrently with error creation.
+// Passing nil restores the default policy, Always.
+//
+// Example:
+//
+//	// Capture a trace for only 1 in 100 errors
+//	stacktrace.SetPolicy(stacktrace.Sample(100))
+func SetPolicy(p Policy) {
+	if p == nil {
+		p = Always()
+	}
+	currentHolder.Store(&policyHolder{policy: p})
+}
+
+// CurrentPolicy returns the capture policy currently in effect.
+func CurrentPolicy() Policy {
+	return currentPolicy()
+}
+
+// currentPolicy returns the active policy, defaulting to Always.
+func currentPolicy() Policy {
+	if h := currentHolder.Load(); h != nil {
+		return h.policy
+	}
+	return Always()
+}
+
+// Always returns a Policy that captures a trace on every call. This is the default.
+func Always() Policy {
+	return alwaysPolicy{}
+}
+
+type alwaysPolicy struct{}
+
+func (alwaysPolicy) ShouldCapture([]errx.Classified) bool {
+	return true
+}
+
+// Never returns a Policy that never captures a trace.
+func Never() Policy {
+	return neverPolicy{}
+}
+
+type neverPolicy struct{}
+
+func (neverPolicy) ShouldCapture([]errx.Classified) bool {
+	return false
+}
+
+// Sample returns a Policy that captures a trace for one in every n calls,
+// starting with the first one. If n is less than or equal to 1, every call is captured.
+//
+// The counter is shared by all callers using the returned Policy.
+func Sample(n int) Policy {
+	if n <= 1 {
+		return Always()
+	}
+	return &samplePolicy{every: uint64(n)}
+}
+
+type samplePolicy struct {
+	every   uint64
+	counter atomic.Uint64
+}
+
+func (p *samplePolicy) ShouldCapture([]errx.Classified) bool {
+	return (p.counter.Add(1)-1)%p.every == 0
+}
+
+// OnlyFor returns a Policy that captures a trace only when one of the classifications
+// passed to the call matches one of the given sentinels via errors.Is. Sentinel
+// hierarchies are respected: a child sentinel matches a policy built for its parent.
+//
+// Only the classifications passed to the call are inspected, not the cause chain.
+//
+// Example:
+//
+//	// Only capture traces for database errors; cache misses stay cheap
+//	stacktrace.SetPolicy(stacktrace.OnlyFor(ErrDatabase))
+func OnlyFor(sentinels ...errx.Classified) Policy {
+	return &sentinelPolicy{sentinels: sentinels}
+}
+
+type sentinelPolicy struct {
+	sentinels []errx.Classified
+}
+
+func (p *sentinelPolicy) ShouldCapture(classifications []errx.Classified) bool {
+	for _, cls := range classifications {
+		for _, s := range p.sentinels {
+			if errors.Is(cls, s) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+//go:build !errx_notrace
+
+package stacktrace_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+// withPolicy sets the capture policy for the duration of the test
+func withPolicy(t *testing.T, p stacktrace.Policy) {
+	t.Helper()
+	prev := stacktrace.CurrentPolicy()
+	stacktrace.SetPolicy(p)
+	t.Cleanup(func() { stacktrace.SetPolicy(prev) })
+}
+
+func TestPolicy_DefaultAlways(t *testing.T) {
+	if stacktrace.CurrentPolicy() != stacktrace.Always() {
+		t.Fatal("Expected default policy to be Always")
+	}
+	if stacktrace.Extract(stacktrace.Wrap("context", errors.New("base"))) == nil {
+		t.Error("Expected stack trace with default policy")
+	}
+}
+
+func TestPolicy_Never(t *testing.T) {
+	withPolicy(t, stacktrace.Never())
+	ErrNotFound := errx.NewSentinel("not found")
+
+	errs := []error{
+		stacktrace.Wrap("context", errors.New("base"), ErrNotFound),
+		stacktrace.Classify(errors.New("base"), ErrNotFound),
+		stacktrace.ClassifyNew("base", ErrNotFound),
+	}
+	for i, err := range errs {
+		if stacktrace.HasTrace(err) {
+			t.Errorf("Error %d: expected no stack trace", i)
+		}
+		if !errors.Is(err, ErrNotFound) {
+			t.Errorf("Error %d: expected classification to be preserved", i)
+		}
+	}
+
+	// Here is an explicit opt-in and ignores the policy
+	if stacktrace.Extract(errx.Classify(errors.New("base"), stacktrace.Here())) == nil {
+		t.Error("Expected Here() to capture a trace regardless of policy")
+	}
+}
+
+func TestPolicy_SetNilRestoresDefault(t *testing.T) {
+	withPolicy(t, stacktrace.Never())
+	stacktrace.SetPolicy(nil)
+	if stacktrace.CurrentPolicy() != stacktrace.Always() {
+		t.Error("Expected SetPolicy(nil) to restore Always")
+	}
+}
+
+func TestPolicy_Sample(t *testing.T) {
+	withPolicy(t, stacktrace.Sample(3))
+
+	captured := 0
+	for range 9 {
+		if stacktrace.HasTrace(stacktrace.ClassifyNew("base")) {
+			captured++
+		}
+	}
+	if captured != 3 {
+		t.Errorf("Expected 3 of 9 errors to be traced, got %d", captured)
+	}
+}
+
+func TestPolicy_SampleFirstCaptured(t *testing.T) {
+	withPolicy(t, stacktrace.Sample(1000))
+	if !stacktrace.HasTrace(stacktrace.ClassifyNew("base")) {
+		t.Error("Expected first sampled error to be traced")
+	}
+	if stacktrace.HasTrace(stacktrace.ClassifyNew("base")) {
+		t.Error("Expected second sampled error not to be traced")
+	}
+}
+
+func TestPolicy_SampleOneOrLess(t *testing.T) {
+	for _, n := range []int{-1, 0, 1} {
+		if stacktrace.Sample(n) != stacktrace.Always() {
+			t.Errorf("Expected Sample(%d) to be Always", n)
+		}
+	}
+}
+
+func TestPolicy_SampleConcurrent(t *testing.T) {
+	p := stacktrace.Sample(4)
+	var (
+		mu       sync.Mutex
+		captured int
+		wg       sync.WaitGroup
+	)
+	for range 8 {
+		wg.Go(func() {
+			for range 100 {
+				if p.ShouldCapture(nil) {
+					mu.Lock()
+					captured++
+					mu.Unlock()
+				}
+			}
+		})
+	}
+	wg.Wait()
+	if captured != 200 {
+		t.Errorf("Expected 200 of 800 calls to capture, got %d", captured)
+	}
+}
+
+func TestPolicy_OnlyFor(t *testing.T) {
+	ErrDatabase := errx.NewSentinel("database")
+	ErrTimeout := errx.NewSentinel("timeout", ErrDatabase)
+	ErrCacheMiss := errx.NewSentinel("cache miss")
+	withPolicy(t, stacktrace.OnlyFor(ErrDatabase))
+
+	if !stacktrace.HasTrace(stacktrace.ClassifyNew("query failed", ErrDatabase)) {
+		t.Error("Expected trace for ErrDatabase")
+	}
+	if !stacktrace.HasTrace(stacktrace.ClassifyNew("query timed out", ErrTimeout)) {
+		t.Error("Expected trace for child of ErrDatabase")
+	}
+	if stacktrace.HasTrace(stacktrace.ClassifyNew("miss", ErrCacheMiss)) {
+		t.Error("Expected no trace for ErrCacheMiss")
+	}
+	if stacktrace.HasTrace(stacktrace.Wrap("context", ErrDatabase)) {
+		t.Error("Expected cause chain not to be inspected")
+	}
+}
+
+func TestPolicy_Func(t *testing.T) {
+	withPolicy(t, stacktrace.PolicyFunc(func(cls []errx.Classified) bool {
+		return len(cls) > 1
+	}))
+
+	if stacktrace.HasTrace(stacktrace.ClassifyNew("base", errx.NewSentinel("a"))) {
+		t.Error("Expected no trace with one classification")
+	}
+	if !stacktrace.HasTrace(stacktrace.ClassifyNew("base", errx.NewSentinel("a"), errx.NewSentinel("b"))) {
+		t.Error("Expected trace with two classifications")
+	}
+}
+
+func TestHasTrace(t *testing.T) {
+	if stacktrace.HasTrace(nil) {
+		t.Error("Expected HasTrace(nil) to be false")
+	}
+	if stacktrace.HasTrace(errors.New("plain")) {
+		t.Error("Expected no trace on plain error")
+	}
+	if !stacktrace.HasTrace(errx.Wrap("context", errors.New("base"), stacktrace.Here())) {
+		t.Error("Expected trace from Here()")
+	}
+}
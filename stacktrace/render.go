@@ -0,0 +1,254 @@
+package stacktrace
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"io/fs"
+	"strings"
+)
+
+// RenderOption configures how stack traces are rendered by Formatter and RenderHTML.
+type RenderOption func(*renderConfig)
+
+// renderConfig holds rendering configuration.
+type renderConfig struct {
+	sourceLines int
+	sourceFS    fs.FS
+}
+
+// newRenderConfig applies opts to the default configuration.
+func newRenderConfig(opts []RenderOption) *renderConfig {
+	cfg := &renderConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+// sourceReader returns a SourceReader for the configuration, or nil if source
+// context is disabled.
+func (c *renderConfig) sourceReader() *SourceReader {
+	if c.sourceLines <= 0 {
+		return nil
+	}
+	return NewSourceReader(c.sourceFS)
+}
+
+// WithSourceContext includes the given number of source lines before and after
+// each frame's line when rendering. The default is 0 (no source context).
+//
+// Example:
+//
+//	fmt.Printf("%+v\n", stacktrace.Formatter(err, stacktrace.WithSourceContext(3)))
+func WithSourceContext(lines int) RenderOption {
+	return func(c *renderConfig) {
+		c.sourceLines = lines
+	}
+}
+
+// WithSourceFS reads source context from fsys instead of from disk.
+// See SourceReader for how frame paths are looked up in fsys.
+//
+// Example:
+//
+//	//go:embed *.go
+//	var sources embed.FS
+//
+//	stacktrace.RenderHTML(w, err, stacktrace.WithSourceContext(5), stacktrace.WithSourceFS(sources))
+func WithSourceFS(fsys fs.FS) RenderOption {
+	return func(c *renderConfig) {
+		c.sourceFS = fsys
+	}
+}
+
+// Formatter returns a fmt.Formatter that renders err with its stack trace.
+//
+// With the %+v verb it writes the error message followed by the first stack trace
+// in the chain (as returned by Extract) and the stacks of any goroutines it was
+// spawned from (see Go), with source context if configured. If no trace can be
+// extracted but an error in the chain implements fmt.Formatter, as errors from
+// other libraries often do, its own %+v rendering is written instead.
+// All other verbs behave as they would for err itself.
+//
+// Example:
+//
+//	log.Printf("%+v", stacktrace.Formatter(err, stacktrace.WithSourceContext(2)))
+//	// failed to fetch user: record missing
+//	// stack trace:
+//	//   main.fetchUser
+//	//       /app/main.go:42
+//	//            40 |     row, err := db.Get(id)
+//	//            41 |     if err != nil {
+//	//       >    42 |         return stacktrace.Wrap("failed to fetch user", err)
+//	//            43 |     }
+//	//            44 |     return row, nil
+//	//   ...
+func Formatter(err error, opts ...RenderOption) fmt.Formatter {
+	return &formatter{err: err, cfg: newRenderConfig(opts)}
+}
+
+type formatter struct {
+	err error
+	cfg *renderConfig
+}
+
+// Format implements fmt.Formatter.
+func (f *formatter) Format(s fmt.State, verb rune) {
+	if f.err == nil {
+		_, _ = io.WriteString(s, "<nil>")
+		return
+	}
+	if verb == 'v' && s.Flag('+') {
+		_, _ = io.WriteString(s, f.err.Error())
+		if frames := Extract(f.err); len(frames) > 0 {
+			writeTrace(s, frames, ExtractAncestors(f.err), f.cfg)
+		} else if foreign := foreignFormatter(f.err); foreign != nil {
+			// The error only exposes its stack through its own %+v rendering
+			_, _ = fmt.Fprintf(s, "\n%+v", foreign)
+		}
+		return
+	}
+	_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), f.err)
+}
+
+// Format implements fmt.Formatter. With the %+v verb the trace's frames are written
+// one per line; all other verbs write the result of Error().
+func (t *traced) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		var ancestors [][]Frame
+		for a := t.ancestor; a != nil; a = a.ancestor {
+			ancestors = append(ancestors, a.frames())
+		}
+		_, _ = io.WriteString(s, t.Error())
+		writeTrace(s, t.frames(), ancestors, &renderConfig{})
+		return
+	}
+	_, _ = fmt.Fprintf(s, fmt.FormatString(s, verb), t.Error())
+}
+
+// writeTrace writes frames and goroutine ancestry in the text format used for %+v.
+func writeTrace(w io.Writer, frames []Frame, ancestors [][]Frame, cfg *renderConfig) {
+	if len(frames) == 0 {
+		return
+	}
+	reader := cfg.sourceReader()
+
+	_, _ = io.WriteString(w, "\nstack trace:")
+	writeFrames(w, frames, reader, cfg.sourceLines)
+	for _, ancestor := range ancestors {
+		_, _ = io.WriteString(w, "\ncreated in goroutine started at:")
+		writeFrames(w, ancestor, reader, cfg.sourceLines)
+	}
+}
+
+// writeFrames writes frames in the text format, with source context if reader is not nil.
+func writeFrames(w io.Writer, frames []Frame, reader *SourceReader, around int) {
+	for _, frame := range frames {
+		_, _ = fmt.Fprintf(w, "\n  %s\n      %s:%d", frame.Function, frame.File, frame.Line)
+		if reader == nil {
+			continue
+		}
+		for _, line := range reader.Context(frame, around) {
+			marker := " "
+			if line.Current {
+				marker = ">"
+			}
+			_, _ = fmt.Fprintf(w, "\n      %s %5d | %s", marker, line.Line, line.Text)
+		}
+	}
+}
+
+// htmlFrame is a frame prepared for the HTML template.
+type htmlFrame struct {
+	Frame
+	Source []SourceLine
+}
+
+// htmlTrace is a titled list of frames prepared for the HTML template.
+type htmlTrace struct {
+	Title  string
+	Frames []htmlFrame
+}
+
+// htmlPage is the data passed to the HTML template.
+type htmlPage struct {
+	Message string
+	Traces  []htmlTrace
+}
+
+var htmlTemplate = template.Must(template.New("stacktrace").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Message}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+h1 { font-size: 1.3em; color: #b00020; }
+h2 { font-size: 1.1em; margin-top: 1.5em; }
+.frame { margin: 0.8em 0; }
+.function { font-family: monospace; font-weight: bold; }
+.location { font-family: monospace; color: #666; }
+pre { background: #f6f6f6; padding: 0.5em; margin: 0.3em 0; overflow-x: auto; }
+.current { background: #ffe08a; display: block; }
+.lineno { color: #999; user-select: none; }
+</style>
+</head>
+<body>
+<h1>{{.Message}}</h1>
+{{range .Traces}}<h2>{{.Title}}</h2>
+{{range .Frames}}<div class="frame">
+<div class="function">{{.Function}}</div>
+<div class="location">{{.File}}:{{.Line}}</div>
+{{if .Source}}<pre>{{range .Source}}<span{{if .Current}} class="current"{{end}}><span class="lineno">{{printf "%5d" .Line}}</span> {{.Text}}
+</span>{{end}}</pre>{{end}}
+</div>
+{{end}}{{end}}</body>
+</html>
+`))
+
+// RenderHTML writes a self-contained HTML page describing err, its stack trace and
+// goroutine ancestry, with source context if configured. It is intended for
+// development error pages and must not be exposed in production, as it reveals
+// internal details and source code.
+//
+// Example:
+//
+//	func devErrorHandler(w http.ResponseWriter, err error) {
+//	    w.Header().Set("Content-Type", "text/html; charset=utf-8")
+//	    w.WriteHeader(http.StatusInternalServerError)
+//	    _ = stacktrace.RenderHTML(w, err, stacktrace.WithSourceContext(5))
+//	}
+func RenderHTML(w io.Writer, err error, opts ...RenderOption) error {
+	if err == nil {
+		return nil
+	}
+
+	cfg := newRenderConfig(opts)
+	reader := cfg.sourceReader()
+	toHTML := func(title string, frames []Frame) htmlTrace {
+		trace := htmlTrace{Title: title, Frames: make([]htmlFrame, len(frames))}
+		for i, frame := range frames {
+			trace.Frames[i].Frame = frame
+			if reader != nil {
+				trace.Frames[i].Source = reader.Context(frame, cfg.sourceLines)
+			}
+		}
+		return trace
+	}
+
+	page := htmlPage{Message: err.Error()}
+	if frames := Extract(err); len(frames) > 0 {
+		page.Traces = append(page.Traces, toHTML("Stack trace", frames))
+		for _, ancestor := range ExtractAncestors(err) {
+			page.Traces = append(page.Traces, toHTML("Created in goroutine started at", ancestor))
+		}
+	}
+
+	var b strings.Builder
+	if execErr := htmlTemplate.Execute(&b, page); execErr != nil {
+		return execErr
+	}
+	_, writeErr := io.WriteString(w, b.String())
+	return writeErr
+}
@@ -0,0 +1,207 @@
+//go:build !errx_notrace
+
+package stacktrace_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+var testSources = fstest.MapFS{
+	"app/main.go": &fstest.MapFile{Data: []byte("package main\n\nfunc main() {\n\trun()\n}\n\nfunc run() {\n\tpanic(\"<boom>\")\n}\n")},
+}
+
+func TestSourceReader_FS(t *testing.T) {
+	reader := stacktrace.NewSourceReader(testSources)
+	frame := stacktrace.Frame{File: "/home/dev/project/app/main.go", Line: 4, Function: "main.main"}
+
+	lines := reader.Context(frame, 1)
+	want := []stacktrace.SourceLine{
+		{Line: 3, Text: "func main() {"},
+		{Line: 4, Text: "\trun()", Current: true},
+		{Line: 5, Text: "}"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines, got %d: %v", len(want), len(lines), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("Line %d: expected %+v, got %+v", i, want[i], lines[i])
+		}
+	}
+}
+
+func TestSourceReader_SameBaseName(t *testing.T) {
+	reader := stacktrace.NewSourceReader(fstest.MapFS{
+		"main.go":            &fstest.MapFile{Data: []byte("package main // root\n")},
+		"cmd/worker/main.go": &fstest.MapFile{Data: []byte("package main // worker\n")},
+	})
+
+	lines := reader.Context(stacktrace.Frame{File: "/home/dev/project/cmd/worker/main.go", Line: 1}, 0)
+	if len(lines) != 1 || lines[0].Text != "package main // worker" {
+		t.Errorf("Expected the worker source, got %+v", lines)
+	}
+	if lines := reader.Context(stacktrace.Frame{File: "/home/dev/project/cmd/server/main.go", Line: 1}, 0); lines != nil {
+		t.Errorf("Expected no source for a file only sharing the base name, got %+v", lines)
+	}
+	lines = reader.Context(stacktrace.Frame{File: "main.go", Line: 1}, 0)
+	if len(lines) != 1 || lines[0].Text != "package main // root" {
+		t.Errorf("Expected a bare frame path to match, got %+v", lines)
+	}
+}
+
+func TestSourceReader_ClampsToFile(t *testing.T) {
+	reader := stacktrace.NewSourceReader(testSources)
+
+	lines := reader.Context(stacktrace.Frame{File: "app/main.go", Line: 1}, 3)
+	if len(lines) != 4 || lines[0].Line != 1 || !lines[0].Current {
+		t.Errorf("Expected lines 1-4 with line 1 current, got %+v", lines)
+	}
+
+	lines = reader.Context(stacktrace.Frame{File: "app/main.go", Line: 9}, 3)
+	if len(lines) != 4 || lines[3].Line != 9 || !lines[3].Current {
+		t.Errorf("Expected lines 6-9 with line 9 current, got %+v", lines)
+	}
+}
+
+func TestSourceReader_Unavailable(t *testing.T) {
+	reader := stacktrace.NewSourceReader(testSources)
+
+	cases := []stacktrace.Frame{
+		{File: "/missing/file.go", Line: 1},
+		{File: "app/main.go", Line: 100},
+		{File: "app/main.go", Line: 0},
+		{File: "", Line: 1},
+	}
+	for _, frame := range cases {
+		if lines := reader.Context(frame, 2); lines != nil {
+			t.Errorf("Expected no source for %v, got %v", frame, lines)
+		}
+	}
+	if lines := reader.Context(stacktrace.Frame{File: "app/main.go", Line: 4}, -1); lines != nil {
+		t.Errorf("Expected no source for negative context, got %v", lines)
+	}
+}
+
+func TestSourceReader_Disk(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	reader := stacktrace.NewSourceReader(nil)
+
+	lines := reader.Context(stacktrace.Frame{File: file, Line: line}, 0)
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0].Text, "runtime.Caller(0)") {
+		t.Errorf("Expected the current line, got %q", lines[0].Text)
+	}
+}
+
+func TestFormatter_PlusV(t *testing.T) {
+	err := stacktrace.Wrap("operation failed", errors.New("base error"))
+
+	out := fmt.Sprintf("%+v", stacktrace.Formatter(err, stacktrace.WithSourceContext(1)))
+	if !strings.HasPrefix(out, "operation failed: base error\nstack trace:\n") {
+		t.Errorf("Expected message and trace header, got:\n%s", out)
+	}
+	if !strings.Contains(out, "stacktrace_test.TestFormatter_PlusV\n") {
+		t.Errorf("Expected test function in trace, got:\n%s", out)
+	}
+	if !strings.Contains(out, `>`) || !strings.Contains(out, `| 	err := stacktrace.Wrap("operation failed"`) {
+		t.Errorf("Expected highlighted source line, got:\n%s", out)
+	}
+}
+
+func TestFormatter_PlusVNoSource(t *testing.T) {
+	err := stacktrace.Wrap("operation failed", errors.New("base error"))
+
+	out := fmt.Sprintf("%+v", stacktrace.Formatter(err))
+	if strings.Contains(out, " | ") {
+		t.Errorf("Expected no source context by default, got:\n%s", out)
+	}
+}
+
+func TestFormatter_OtherVerbs(t *testing.T) {
+	err := stacktrace.Wrap("operation failed", errors.New("base error"))
+	f := stacktrace.Formatter(err)
+
+	if got := fmt.Sprintf("%v", f); got != err.Error() {
+		t.Errorf("%%v: expected %q, got %q", err.Error(), got)
+	}
+	if got := fmt.Sprintf("%s", f); got != err.Error() {
+		t.Errorf("%%s: expected %q, got %q", err.Error(), got)
+	}
+	if got := fmt.Sprintf("%q", f); got != fmt.Sprintf("%q", err.Error()) {
+		t.Errorf("%%q: expected quoted message, got %s", got)
+	}
+	if got := fmt.Sprintf("%+v", stacktrace.Formatter(nil)); got != "<nil>" {
+		t.Errorf("Expected <nil>, got %q", got)
+	}
+}
+
+func TestFormatter_NoTrace(t *testing.T) {
+	err := errx.Wrap("operation failed", errors.New("base error"))
+	if got := fmt.Sprintf("%+v", stacktrace.Formatter(err)); got != err.Error() {
+		t.Errorf("Expected just the message, got %q", got)
+	}
+}
+
+func TestHere_FormatPlusV(t *testing.T) {
+	trace := stacktrace.Here()
+	out := fmt.Sprintf("%+v", trace)
+	if !strings.Contains(out, "stacktrace_test.TestHere_FormatPlusV") {
+		t.Errorf("Expected frames in %%+v output, got:\n%s", out)
+	}
+	if got := fmt.Sprintf("%v", trace); got != trace.Error() {
+		t.Errorf("Expected %%v to match Error(), got %q", got)
+	}
+}
+
+func TestRenderHTML(t *testing.T) {
+	err := stacktrace.Wrap("<script>alert(1)</script>", errors.New("base error"))
+
+	var b strings.Builder
+	if renderErr := stacktrace.RenderHTML(&b, err, stacktrace.WithSourceContext(2)); renderErr != nil {
+		t.Fatalf("RenderHTML error: %v", renderErr)
+	}
+	out := b.String()
+
+	if strings.Contains(out, "<script>") {
+		t.Error("Expected message to be HTML-escaped")
+	}
+	if !strings.Contains(out, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Error("Expected escaped message in output")
+	}
+	if !strings.Contains(out, "stacktrace_test.TestRenderHTML") {
+		t.Error("Expected test function in output")
+	}
+	if !strings.Contains(out, `class="current"`) {
+		t.Error("Expected highlighted source line")
+	}
+}
+
+func TestRenderHTML_SourceFS(t *testing.T) {
+	err := stacktrace.Wrap("failed", errors.New("base error"))
+
+	var b strings.Builder
+	renderErr := stacktrace.RenderHTML(&b, err, stacktrace.WithSourceContext(2), stacktrace.WithSourceFS(testSources))
+	if renderErr != nil {
+		t.Fatalf("RenderHTML error: %v", renderErr)
+	}
+	if strings.Contains(b.String(), `class="current"`) {
+		t.Error("Expected no source when the FS does not contain the frame files")
+	}
+}
+
+func TestRenderHTML_Nil(t *testing.T) {
+	var b strings.Builder
+	if err := stacktrace.RenderHTML(&b, nil); err != nil || b.Len() != 0 {
+		t.Errorf("Expected no output for nil error, got %q (%v)", b.String(), err)
+	}
+}
@@ -0,0 +1,89 @@
+package stacktrace
+
+import (
+	"github.com/go-extras/errx/internal/errchain"
+)
+
+// step is a transparent wrapper that records a single propagation location.
+// Its message is the message of the wrapped error, so adding steps never changes
+// what Error() returns.
+type step struct {
+	errchain.Transparent
+	err error
+	pc  uintptr
+}
+
+func (s *step) Error() string {
+	return s.err.Error()
+}
+
+func (s *step) Unwrap() error {
+	return s.err
+}
+
+// Propagate records the caller's location as one step of err's return trace and
+// returns err wrapped so that errors.Is, errors.As and Error() behave exactly as before.
+//
+// Unlike a full stack trace, a step costs a single program counter. Calling Propagate
+// at each `if err != nil { return ... }` site builds a trace of the path the error took
+// back up the call stack, which can be retrieved with ReturnTrace.
+//
+// Propagate is not subject to the capture Policy. When built with the errx_notrace tag
+// it returns err unchanged. If err is nil, Propagate returns nil.
+//
+// Example:
+//
+//	user, err := loadUser(id)
+//	if err != nil {
+//	    return stacktrace.Propagate(err)
+//	}
+//
+//	// Or combined with context and classifications
+//	return errx.Wrap("failed to load user", stacktrace.Propagate(err), ErrNotFound)
+func Propagate(err error) error {
+	if err == nil {
+		return nil
+	}
+	// Capture with skip=2 to skip Propagate() and runtime.Callers
+	pc := captureCaller(2)
+	if pc == 0 {
+		return err
+	}
+	return &step{err: err, pc: pc}
+}
+
+// ReturnTrace returns the locations recorded by Propagate in err's chain, ordered
+// from the step closest to where the error originated to the outermost step.
+//
+// Returns nil if the error is nil or no steps were recorded.
+//
+// Example:
+//
+//	for _, frame := range stacktrace.ReturnTrace(err) {
+//	    fmt.Printf("returned from %s:%d %s\n", frame.File, frame.Line, frame.Function)
+//	}
+func ReturnTrace(err error) []Frame {
+	if err == nil {
+		return nil
+	}
+
+	// Steps are collected from the outermost inwards, following every branch of multi-errors
+	var pcs []uintptr
+	errchain.Walk(err, func(e error) bool {
+		if s, ok := e.(*step); ok {
+			pcs = append(pcs, s.pc)
+		}
+		return false
+	})
+
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	frames := make([]Frame, len(pcs))
+	for i, pc := range pcs {
+		// The location closest to the origin comes first
+		frames[len(pcs)-1-i] = resolvePC(pc)[0]
+	}
+	return frames
+}
@@ -0,0 +1,106 @@
+//go:build !errx_notrace
+
+package stacktrace_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/stacktrace"
+)
+
+var errReturnBase = errors.New("record missing")
+
+//go:noinline
+func returnTraceInner() error {
+	return stacktrace.Propagate(errReturnBase)
+}
+
+//go:noinline
+func returnTraceMiddle() error {
+	if err := returnTraceInner(); err != nil {
+		return errx.Wrap("load user", stacktrace.Propagate(err))
+	}
+	return nil
+}
+
+//go:noinline
+func returnTraceOuter() error {
+	if err := returnTraceMiddle(); err != nil {
+		return stacktrace.Propagate(err)
+	}
+	return nil
+}
+
+func TestPropagate_Nil(t *testing.T) {
+	if err := stacktrace.Propagate(nil); err != nil {
+		t.Errorf("Expected nil, got %v", err)
+	}
+}
+
+func TestPropagate_Transparent(t *testing.T) {
+	ErrNotFound := errx.NewSentinel("not found")
+	base := errx.Classify(errReturnBase, ErrNotFound, errx.Attrs("id", 42))
+	err := stacktrace.Propagate(base)
+
+	if err.Error() != base.Error() {
+		t.Errorf("Expected message %q, got %q", base.Error(), err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, errReturnBase) {
+		t.Error("Expected errors.Is to see through the step")
+	}
+	if len(errx.ExtractAttrs(err)) != 1 {
+		t.Error("Expected attributes to be preserved")
+	}
+	if stacktrace.HasTrace(err) {
+		t.Error("Expected a step not to count as a full stack trace")
+	}
+}
+
+func TestReturnTrace_Order(t *testing.T) {
+	err := returnTraceOuter()
+
+	frames := stacktrace.ReturnTrace(err)
+	if len(frames) != 3 {
+		t.Fatalf("Expected 3 steps, got %d: %v", len(frames), frames)
+	}
+
+	want := []string{"returnTraceInner", "returnTraceMiddle", "returnTraceOuter"}
+	for i, name := range want {
+		if !strings.HasSuffix(frames[i].Function, name) {
+			t.Errorf("Step %d: expected function %s, got %s", i, name, frames[i].Function)
+		}
+		if !strings.HasSuffix(frames[i].File, "return_test.go") {
+			t.Errorf("Step %d: expected file return_test.go, got %s", i, frames[i].File)
+		}
+	}
+}
+
+func TestReturnTrace_NoSteps(t *testing.T) {
+	if frames := stacktrace.ReturnTrace(nil); frames != nil {
+		t.Errorf("Expected nil for nil error, got %v", frames)
+	}
+	err := stacktrace.Wrap("context", errors.New("base"))
+	if frames := stacktrace.ReturnTrace(err); frames != nil {
+		t.Errorf("Expected nil without steps, got %v", frames)
+	}
+}
+
+func TestReturnTrace_MultiError(t *testing.T) {
+	first := stacktrace.Propagate(errors.New("first"))
+	second := stacktrace.Propagate(errors.New("second"))
+	err := fmt.Errorf("both: %w", errors.Join(first, second))
+
+	frames := stacktrace.ReturnTrace(err)
+	if len(frames) != 2 {
+		t.Fatalf("Expected 2 steps, got %d", len(frames))
+	}
+	for i, frame := range frames {
+		if !strings.HasSuffix(frame.Function, "TestReturnTrace_MultiError") {
+			t.Errorf("Step %d: expected test function, got %s", i, frame.Function)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package stacktrace
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+	"sync"
+)
+
+// SourceLine is a single line of source code surrounding a stack frame.
+type SourceLine struct {
+	Line    int    // Line number in the source file (1-based)
+	Text    string // Line contents without the trailing newline
+	Current bool   // Whether this is the line the frame points to
+}
+
+// SourceReader reads source code surrounding stack frames, caching file contents
+// so that rendering many frames from the same file reads it only once.
+// It is safe for concurrent use.
+//
+// By default files are read from disk using the absolute paths recorded in frames.
+// When a fs.FS is provided, files are looked up in it instead: first by the frame's
+// path without its leading slash, then by successively shorter suffixes of that path
+// that still include the file's directory. A bare file name is never matched against a
+// longer frame path, since many files share names like main.go.
+// This allows serving sources from an embed.FS rooted at the module directory, or
+// from an fstest.MapFS in tests.
+type SourceReader struct {
+	fsys fs.FS
+
+	mu    sync.Mutex
+	files map[string][]string // File path to its lines; nil entry for unreadable files
+}
+
+// NewSourceReader creates a SourceReader that reads from fsys, or from disk if fsys is nil.
+func NewSourceReader(fsys fs.FS) *SourceReader {
+	return &SourceReader{
+		fsys:  fsys,
+		files: make(map[string][]string),
+	}
+}
+
+// Context returns up to around lines before and after the frame's line, with the
+// frame's own line marked as Current. It returns nil if around is negative or the
+// source is not available.
+//
+// Example:
+//
+//	reader := stacktrace.NewSourceReader(nil)
+//	for _, line := range reader.Context(frame, 2) {
+//	    fmt.Printf("%4d | %s\n", line.Line, line.Text)
+//	}
+func (r *SourceReader) Context(frame Frame, around int) []SourceLine {
+	if around < 0 || frame.File == "" || frame.Line <= 0 {
+		return nil
+	}
+
+	lines := r.lines(frame.File)
+	if frame.Line > len(lines) {
+		return nil
+	}
+
+	start := max(frame.Line-around, 1)
+	end := min(frame.Line+around, len(lines))
+	result := make([]SourceLine, 0, end-start+1)
+	for n := start; n <= end; n++ {
+		result = append(result, SourceLine{
+			Line:    n,
+			Text:    lines[n-1],
+			Current: n == frame.Line,
+		})
+	}
+	return result
+}
+
+// lines returns the lines of file, reading and caching them on first use.
+func (r *SourceReader) lines(file string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if lines, ok := r.files[file]; ok {
+		return lines
+	}
+
+	var lines []string
+	if data, err := r.read(file); err == nil {
+		data = bytes.TrimSuffix(data, []byte("\n"))
+		lines = strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	}
+	r.files[file] = lines
+	return lines
+}
+
+// read returns the contents of file from the configured source.
+func (r *SourceReader) read(file string) ([]byte, error) {
+	if r.fsys == nil {
+		return os.ReadFile(file) //nolint:gosec // paths come from runtime frame information
+	}
+
+	name := strings.TrimPrefix(path.Clean(strings.ReplaceAll(file, "\\", "/")), "/")
+	for {
+		data, err := fs.ReadFile(r.fsys, name)
+		if err == nil {
+			return data, nil
+		}
+		// Stop before the bare file name, which would match any file with that name
+		_, rest, _ := strings.Cut(name, "/")
+		if !strings.Contains(rest, "/") {
+			return nil, err
+		}
+		name = rest
+	}
+}
@@ -15,12 +15,21 @@
 //	for _, frame := range frames {
 //	    fmt.Printf("%s:%d %s\n", frame.File, frame.Line, frame.Function)
 //	}
+//
+// # Capture Cost
+//
+// Capturing a trace walks the stack with runtime.Callers. For hot paths the automatic
+// capture done by Wrap, Classify and ClassifyNew can be limited at runtime with
+// SetPolicy (always, never, 1-in-N sampling, or only for specific sentinels).
+// Building with the errx_notrace tag compiles capture out entirely while keeping
+// the API intact: Extract then always returns nil.
 package stacktrace
 
 import (
 	"errors"
 	"fmt"
-	"runtime"
+	"slices"
+	"sync"
 
 	"github.com/go-extras/errx"
 )
@@ -39,7 +48,11 @@ func (f Frame) String() string {
 
 // traced is an internal type that implements errx.Classified and captures stack trace.
 type traced struct {
-	pcs []uintptr // Program counters captured from the stack
+	pcs      []uintptr // Program counters captured from the stack
+	ancestor *traced   // Stack of the Go call whose context the trace was captured with, if any
+
+	once     sync.Once // Guards resolved
+	resolved []Frame   // Frames resolved from pcs, populated on first use
 }
 
 // Error returns a string representation of the traced error.
@@ -53,26 +66,21 @@ func (t *traced) Error() string {
 }
 
 // frames converts the stored program counters into Frame structs.
-// This is done lazily to avoid the cost of frame resolution unless needed.
+// This is done lazily to avoid the cost of frame resolution unless needed,
+// and only once per trace; subsequent calls return the cached result.
+// The returned slice is shared and must not be modified.
 func (t *traced) frames() []Frame {
-	if len(t.pcs) == 0 {
-		return nil
-	}
+	t.once.Do(func() {
+		t.resolved = resolveFrames(t.pcs)
+	})
+	return t.resolved
+}
 
-	frames := runtime.CallersFrames(t.pcs)
-	var result []Frame
-	for {
-		frame, more := frames.Next()
-		result = append(result, Frame{
-			File:     frame.File,
-			Line:     frame.Line,
-			Function: frame.Function,
-		})
-		if !more {
-			break
-		}
-	}
-	return result
+// Callers returns a copy of the program counters of the trace. It follows the
+// convention of github.com/go-errors/errors, so that code not importing this
+// package, such as errx.Fingerprint, can read traces structurally.
+func (t *traced) Callers() []uintptr {
+	return slices.Clone(t.pcs)
 }
 
 // IsClassified implements the errx.Classified interface marker method.
@@ -92,22 +100,24 @@ func (*traced) IsClassified() bool {
 //	err := errx.Wrap("operation failed", cause, ErrNotFound, stacktrace.Here())
 //
 // The captured stack trace can later be extracted using Extract().
+//
+// Here is an explicit opt-in and is not subject to the capture Policy. When built
+// with the errx_notrace tag it returns an empty trace, for which Extract returns nil.
 func Here() errx.Classified {
-	return captureStack(2) // Skip Here() and runtime.Callers
-}
-
-// captureStack captures the current stack trace with the specified skip count.
-// skip indicates how many stack frames to skip (0 = captureStack itself).
-func captureStack(skip int) *traced {
-	const maxDepth = 32 // Reasonable default depth limit
-	pcs := make([]uintptr, maxDepth)
-	n := runtime.Callers(skip+1, pcs) // +1 to skip captureStack itself
-	return &traced{pcs: pcs[:n]}
+	return captureStack(2, nil) // Skip Here() and runtime.Callers
 }
 
 // Extract returns stack frames from the first traced error found in the error chain.
 // It traverses the entire error chain looking for a traced error and returns its frames.
 //
+// If the chain contains no errx trace, Extract falls back to stack traces exposed by
+// other error libraries, recognized by their methods without importing them:
+// Callers() []uintptr (github.com/go-errors/errors) and StackTrace() returning a
+// slice of program counters (github.com/pkg/errors).
+//
+// Frames are resolved once per trace and cached, so repeated calls are cheap.
+// The returned slice is a copy and may be modified freely by the caller.
+//
 // Returns nil if the error is nil or does not contain any stack trace.
 //
 // Example:
@@ -126,10 +136,57 @@ func Extract(err error) []Frame {
 	// Use errors.As to find the first traced error in the chain
 	var t *traced
 	if errors.As(err, &t) {
-		return t.frames()
+		if frames := t.frames(); len(frames) > 0 {
+			return slices.Clone(frames)
+		}
 	}
 
-	return nil
+	return resolveFrames(foreignPCs(err))
+}
+
+// ExtractAncestors returns the spawn stacks linked to the first traced error found in
+// the error chain. When the trace was captured with the context of a goroutine started
+// with Go (see WrapCtx, ClassifyCtx and HereCtx), the first element is the stack of that
+// Go call; if the spawning goroutine was itself started with Go, the next element is
+// its spawn stack, and so on.
+//
+// Returns nil if the error is nil, does not contain a stack trace, or the trace was
+// not captured with the context of a goroutine started with Go.
+//
+// Example:
+//
+//	for _, spawn := range stacktrace.ExtractAncestors(err) {
+//	    fmt.Println("created in goroutine started at", spawn[0])
+//	}
+func ExtractAncestors(err error) [][]Frame {
+	if err == nil {
+		return nil
+	}
+
+	var t *traced
+	if !errors.As(err, &t) {
+		return nil
+	}
+
+	var result [][]Frame
+	for a := t.ancestor; a != nil; a = a.ancestor {
+		result = append(result, slices.Clone(a.frames()))
+	}
+	return result
+}
+
+// HasTrace reports whether any error in err's chain is a stack trace classification
+// created by this package. Unlike Extract, it does not resolve any frames.
+//
+// HasTrace also reports true for empty traces, such as those returned by Here when
+// built with the errx_notrace tag; Extract returns nil for those.
+func HasTrace(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var t *traced
+	return errors.As(err, &t)
 }
 
 // Wrap wraps an error with additional context text and optional classifications,
@@ -139,6 +196,8 @@ func Extract(err error) []Frame {
 //
 //	errx.Wrap(text, cause, append(classifications, stacktrace.Here())...)
 //
+// The trace is only captured if the current Policy allows it (see SetPolicy).
+//
 // If cause is nil, Wrap returns nil.
 //
 // Example:
@@ -148,9 +207,10 @@ func Wrap(text string, cause error, classifications ...errx.Classified) error {
 	if cause == nil {
 		return nil
 	}
-	// Capture stack with skip=2 to skip Wrap() and runtime.Callers
-	trace := captureStack(2)
-	classifications = append(classifications, trace)
+	if shouldCapture(classifications) {
+		// Capture stack with skip=2 to skip Wrap() and runtime.Callers
+		classifications = append(slices.Clip(classifications), captureStack(2, nil))
+	}
 	return errx.Wrap(text, cause, classifications...)
 }
 
@@ -161,6 +221,8 @@ func Wrap(text string, cause error, classifications ...errx.Classified) error {
 //
 //	errx.Classify(cause, append(classifications, stacktrace.Here())...)
 //
+// The trace is only captured if the current Policy allows it (see SetPolicy).
+//
 // If cause is nil, Classify returns nil.
 //
 // Example:
@@ -170,9 +232,10 @@ func Classify(cause error, classifications ...errx.Classified) error {
 	if cause == nil {
 		return nil
 	}
-	// Capture stack with skip=2 to skip Classify() and runtime.Callers
-	trace := captureStack(2)
-	classifications = append(classifications, trace)
+	if shouldCapture(classifications) {
+		// Capture stack with skip=2 to skip Classify() and runtime.Callers
+		classifications = append(slices.Clip(classifications), captureStack(2, nil))
+	}
 	return errx.Classify(cause, classifications...)
 }
 
@@ -186,6 +249,8 @@ func Classify(cause error, classifications ...errx.Classified) error {
 // This function is useful when you want to create a new error, classify it, and
 // capture a stack trace in a single step, reducing verbosity.
 //
+// The trace is only captured if the current Policy allows it (see SetPolicy).
+//
 // Example:
 //
 //	var ErrNotFound = errx.NewSentinel("not found")
@@ -201,8 +266,9 @@ func Classify(cause error, classifications ...errx.Classified) error {
 //	fmt.Println(errors.Is(err, ErrNotFound))        // Output: true
 //	fmt.Println(stacktrace.Extract(err) != nil)     // Output: true
 func ClassifyNew(text string, classifications ...errx.Classified) error {
-	// Capture stack with skip=2 to skip ClassifyNew() and runtime.Callers
-	trace := captureStack(2)
-	classifications = append(classifications, trace)
+	if shouldCapture(classifications) {
+		// Capture stack with skip=2 to skip ClassifyNew() and runtime.Callers
+		classifications = append(slices.Clip(classifications), captureStack(2, nil))
+	}
 	return errx.ClassifyNew(text, classifications...)
 }
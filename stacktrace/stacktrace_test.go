@@ -1,8 +1,11 @@
+//go:build !errx_notrace
+
 package stacktrace_test
 
 import (
 	"errors"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-extras/errx"
@@ -402,3 +405,55 @@ func TestClassifyNewNoClassifications(t *testing.T) {
 		t.Error("Expected stack trace even without classifications")
 	}
 }
+
+// TestExtractCachedFrames verifies that repeated extraction returns identical frames
+// and that modifying a returned slice does not affect subsequent extractions
+func TestExtractCachedFrames(t *testing.T) {
+	err := stacktrace.Wrap("context", errors.New("base"))
+
+	first := stacktrace.Extract(err)
+	if len(first) == 0 {
+		t.Fatal("Expected stack trace")
+	}
+	want := first[0]
+
+	first[0] = stacktrace.Frame{File: "modified.go", Line: 1, Function: "modified"}
+
+	second := stacktrace.Extract(err)
+	if len(second) != len(first) {
+		t.Fatalf("Expected %d frames, got %d", len(first), len(second))
+	}
+	if second[0] != want {
+		t.Errorf("Expected cached frame %v, got %v", want, second[0])
+	}
+}
+
+// TestExtractConcurrent verifies that frame resolution is safe for concurrent use
+func TestExtractConcurrent(t *testing.T) {
+	err := stacktrace.Wrap("context", errors.New("base"))
+
+	const workers = 8
+	results := make([][]stacktrace.Frame, workers)
+	var wg sync.WaitGroup
+	for i := range workers {
+		wg.Go(func() {
+			_ = err.Error()
+			results[i] = stacktrace.Extract(err)
+		})
+	}
+	wg.Wait()
+
+	if len(results[0]) == 0 {
+		t.Fatal("Expected stack trace")
+	}
+	for i, frames := range results {
+		if len(frames) != len(results[0]) {
+			t.Fatalf("Worker %d: expected %d frames, got %d", i, len(results[0]), len(frames))
+		}
+		for j := range frames {
+			if frames[j] != results[0][j] {
+				t.Errorf("Worker %d: frame %d differs: %v vs %v", i, j, frames[j], results[0][j])
+			}
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package tracectx_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/tracectx"
+)
+
+// Example demonstrates attaching the trace IDs of an inbound request to errors
+func Example() {
+	handler := tracectx.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		err := tracectx.WrapCtx(r.Context(), "failed to load order", errors.New("no rows"), ErrNotFound)
+		fmt.Println(errx.ExtractAttrs(err))
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// Output:
+	// trace_id=4bf92f3577b34da6a3ce929d0e0e4736 span_id=00f067aa0ba902b7
+}
+
+// ExampleSetProvider demonstrates supplying span IDs from a tracer
+func ExampleSetProvider() {
+	type spanKey struct{}
+	tracectx.SetProvider(tracectx.ProviderFunc(func(ctx context.Context) (tracectx.SpanContext, bool) {
+		// With OpenTelemetry, read trace.SpanContextFromContext(ctx) instead
+		sc, ok := ctx.Value(spanKey{}).(tracectx.SpanContext)
+		return sc, ok
+	}))
+	defer tracectx.SetProvider(nil)
+
+	ctx := context.WithValue(context.Background(), spanKey{}, tracectx.SpanContext{
+		TraceID: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:  "b7ad6b7169203331",
+	})
+	err := tracectx.ClassifyCtx(ctx, errors.New("timeout"))
+	fmt.Println(tracectx.IDs(err))
+
+	// Output:
+	// 4bf92f3577b34da6a3ce929d0e0e4736 b7ad6b7169203331
+}
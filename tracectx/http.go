@@ -0,0 +1,42 @@
+package tracectx
+
+import (
+	"net/http"
+)
+
+// TraceparentHeader is the name of the W3C Trace Context header.
+const TraceparentHeader = "Traceparent"
+
+// FromHeader extracts the span context from a traceparent header.
+// It reports false if the header is missing or malformed.
+func FromHeader(h http.Header) (SpanContext, bool) {
+	values := h.Values(TraceparentHeader)
+	if len(values) != 1 {
+		// The specification requires a single header; treat duplicates as invalid
+		return SpanContext{}, false
+	}
+	sc, err := ParseTraceparent(values[0])
+	if err != nil {
+		return SpanContext{}, false
+	}
+	return sc, true
+}
+
+// FromRequest extracts the span context from the traceparent header of r.
+func FromRequest(r *http.Request) (SpanContext, bool) {
+	return FromHeader(r.Header)
+}
+
+// Middleware stores the span context of the inbound traceparent header, if any,
+// in the request context, where WrapCtx, ClassifyCtx and FromContext find it.
+//
+// The span ID of an inbound header identifies the caller's span. When a tracer
+// creates a server span, register a Provider so that errors carry that span instead.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if sc, ok := FromRequest(r); ok {
+			r = r.WithContext(ContextWithSpan(r.Context(), sc))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
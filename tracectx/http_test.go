@@ -0,0 +1,52 @@
+package tracectx_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-extras/errx/tracectx"
+)
+
+func TestFromHeader(t *testing.T) {
+	h := http.Header{}
+	if _, ok := tracectx.FromHeader(h); ok {
+		t.Error("FromHeader(empty) ok = true, want false")
+	}
+
+	h.Set("traceparent", "00-"+testTraceID+"-"+testSpanID+"-01")
+	sc, ok := tracectx.FromHeader(h)
+	if !ok || sc.TraceID != testTraceID || sc.SpanID != testSpanID || !sc.Sampled {
+		t.Errorf("FromHeader() = %+v, %v", sc, ok)
+	}
+
+	h.Add("traceparent", "00-"+testTraceID+"-b7ad6b7169203331-01")
+	if _, ok := tracectx.FromHeader(h); ok {
+		t.Error("FromHeader(duplicate headers) ok = true, want false")
+	}
+
+	h.Set("traceparent", "garbage")
+	if _, ok := tracectx.FromHeader(h); ok {
+		t.Error("FromHeader(malformed) ok = true, want false")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	var got tracectx.SpanContext
+	var found bool
+	handler := tracectx.Middleware(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got, found = tracectx.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	req.Header.Set("traceparent", "00-"+testTraceID+"-"+testSpanID+"-00")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if !found || got.TraceID != testTraceID || got.SpanID != testSpanID {
+		t.Errorf("FromContext() in handler = %+v, %v", got, found)
+	}
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	if found {
+		t.Error("FromContext() without header ok = true, want false")
+	}
+}
@@ -0,0 +1,234 @@
+// Package tracectx attaches distributed tracing identifiers to errx errors.
+//
+// It extracts W3C Trace Context identifiers (the trace ID and span ID of a
+// traceparent header) from inbound HTTP requests or from a tracer, and attaches
+// them to errors as the well-known attributes "trace_id" and "span_id", so that
+// logs, JSON output and problem+json responses can be correlated with traces.
+//
+// No tracing library is required. Identifiers come from one of two places:
+//   - a traceparent header, stored in the context by Middleware or ContextWithSpan
+//   - a Provider registered with SetProvider, which asks your tracer for the current span
+//
+// # Basic Usage
+//
+//	handler = tracectx.Middleware(handler)
+//	...
+//	return tracectx.WrapCtx(ctx, "failed to load order", err, ErrNotFound)
+//
+// # Tracer Integration
+//
+//	tracectx.SetProvider(tracectx.ProviderFunc(func(ctx context.Context) (tracectx.SpanContext, bool) {
+//	    sc := trace.SpanContextFromContext(ctx) // OpenTelemetry
+//	    return tracectx.SpanContext{
+//	        TraceID: sc.TraceID().String(),
+//	        SpanID:  sc.SpanID().String(),
+//	        Sampled: sc.IsSampled(),
+//	    }, sc.IsValid()
+//	}))
+package tracectx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/go-extras/errx"
+)
+
+// Well-known attribute keys under which trace identifiers are attached to errors.
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// ErrInvalidTraceparent is returned by ParseTraceparent for malformed headers.
+var ErrInvalidTraceparent = errors.New("tracectx: invalid traceparent")
+
+// SpanContext identifies a span within a trace.
+// TraceID and SpanID are lowercase hex strings of 32 and 16 characters.
+type SpanContext struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+// IsValid reports whether sc holds well-formed, non-zero trace and span IDs.
+func (sc SpanContext) IsValid() bool {
+	return isID(sc.TraceID, 32) && isID(sc.SpanID, 16)
+}
+
+// Traceparent formats sc as a version 00 traceparent header value.
+// It returns "" if sc is not valid.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID + "-" + sc.SpanID + "-" + flags
+}
+
+// Attrs returns the trace and span IDs as the well-known attributes.
+// It returns nil if sc is not valid.
+func (sc SpanContext) Attrs() errx.AttrList {
+	if !sc.IsValid() {
+		return nil
+	}
+	return errx.AttrList{
+		{Key: TraceIDKey, Value: sc.TraceID},
+		{Key: SpanIDKey, Value: sc.SpanID},
+	}
+}
+
+// ParseTraceparent parses a W3C traceparent header value of the form
+// "version-traceid-parentid-flags".
+//
+// Version 00 values must have exactly four fields. As the specification requires,
+// values with a higher version are accepted as long as their first four fields
+// are well-formed, and version ff is rejected. All-zero IDs are rejected.
+func ParseTraceparent(header string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, header)
+	}
+	version, traceID, spanID, flags := parts[0], parts[1], parts[2], parts[3]
+	if !isHex(version, 2) || version == "ff" || (version == "00" && len(parts) != 4) {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, header)
+	}
+	if !isID(traceID, 32) || !isID(spanID, 16) || !isHex(flags, 2) {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, header)
+	}
+	return SpanContext{
+		TraceID: traceID,
+		SpanID:  spanID,
+		Sampled: hexValue(flags[1])&1 == 1,
+	}, nil
+}
+
+// isID reports whether s is a lowercase hex string of length n that is not all zeros.
+func isID(s string, n int) bool {
+	return isHex(s, n) && strings.Trim(s, "0") != ""
+}
+
+// isHex reports whether s is a lowercase hex string of length n.
+func isHex(s string, n int) bool {
+	if len(s) != n {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// hexValue returns the value of a lowercase hex digit.
+func hexValue(c byte) byte {
+	if c >= 'a' {
+		return c - 'a' + 10
+	}
+	return c - '0'
+}
+
+// Provider supplies the span context of the current span, typically from a tracer.
+// SpanContext must be safe for concurrent use and report false if ctx has no span.
+type Provider interface {
+	SpanContext(ctx context.Context) (SpanContext, bool)
+}
+
+// ProviderFunc adapts an ordinary function to the Provider interface.
+type ProviderFunc func(ctx context.Context) (SpanContext, bool)
+
+// SpanContext calls f(ctx).
+func (f ProviderFunc) SpanContext(ctx context.Context) (SpanContext, bool) {
+	return f(ctx)
+}
+
+// providerHolder wraps a Provider so it can be stored in an atomic.Pointer.
+type providerHolder struct {
+	provider Provider
+}
+
+var currentProvider atomic.Pointer[providerHolder]
+
+// SetProvider sets the Provider consulted by FromContext before the span context
+// stored in the context. It is safe to call at any time. Passing nil removes the provider.
+func SetProvider(p Provider) {
+	if p == nil {
+		currentProvider.Store(nil)
+		return
+	}
+	currentProvider.Store(&providerHolder{provider: p})
+}
+
+// ctxSpanKey is the context key for span contexts stored with ContextWithSpan.
+type ctxSpanKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying sc. Invalid span contexts are ignored.
+func ContextWithSpan(ctx context.Context, sc SpanContext) context.Context {
+	if !sc.IsValid() {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxSpanKey{}, sc)
+}
+
+// FromContext returns the span context for ctx. The registered Provider is asked
+// first, since it knows the current span; otherwise the span context stored with
+// ContextWithSpan or Middleware is returned.
+func FromContext(ctx context.Context) (SpanContext, bool) {
+	if h := currentProvider.Load(); h != nil {
+		if sc, ok := h.provider.SpanContext(ctx); ok && sc.IsValid() {
+			return sc, true
+		}
+	}
+	sc, ok := ctx.Value(ctxSpanKey{}).(SpanContext)
+	return sc, ok
+}
+
+// WrapCtx is like errx.WrapCtx, but also attaches the trace and span IDs of ctx
+// (see FromContext) as the "trace_id" and "span_id" attributes.
+// If cause is nil, WrapCtx returns nil.
+func WrapCtx(ctx context.Context, text string, cause error, classifications ...errx.Classified) error {
+	return errx.WrapCtx(withTraceAttrs(ctx), text, cause, classifications...)
+}
+
+// ClassifyCtx is like errx.ClassifyCtx, but also attaches the trace and span IDs of ctx
+// (see FromContext) as the "trace_id" and "span_id" attributes.
+// If cause is nil, ClassifyCtx returns nil.
+func ClassifyCtx(ctx context.Context, cause error, classifications ...errx.Classified) error {
+	return errx.ClassifyCtx(withTraceAttrs(ctx), cause, classifications...)
+}
+
+// withTraceAttrs adds the trace attributes of ctx to its errx context attributes,
+// so that errx deduplicates them against attributes already in the error chain.
+func withTraceAttrs(ctx context.Context) context.Context {
+	sc, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return errx.ContextWithAttrs(ctx, sc.Attrs())
+}
+
+// IDs returns the trace and span IDs attached to err, or empty strings if none are.
+// If several are attached, the outermost ones are returned.
+func IDs(err error) (traceID, spanID string) {
+	for _, attr := range errx.ExtractAttrs(err) {
+		switch attr.Key {
+		case TraceIDKey:
+			if s, ok := attr.Value.(string); ok && traceID == "" {
+				traceID = s
+			}
+		case SpanIDKey:
+			if s, ok := attr.Value.(string); ok && spanID == "" {
+				spanID = s
+			}
+		}
+	}
+	return traceID, spanID
+}
@@ -0,0 +1,167 @@
+package tracectx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-extras/errx"
+	"github.com/go-extras/errx/tracectx"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+var ErrNotFound = errx.NewSentinel("not found")
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    tracectx.SpanContext
+		wantErr bool
+	}{
+		{"sampled", "00-" + testTraceID + "-" + testSpanID + "-01", tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID, Sampled: true}, false},
+		{"not sampled", "00-" + testTraceID + "-" + testSpanID + "-00", tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID}, false},
+		{"surrounding spaces", " 00-" + testTraceID + "-" + testSpanID + "-01 ", tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID, Sampled: true}, false},
+		{"future version with extra fields", "01-" + testTraceID + "-" + testSpanID + "-03-extra", tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID, Sampled: true}, false},
+		{"version 00 with extra fields", "00-" + testTraceID + "-" + testSpanID + "-01-extra", tracectx.SpanContext{}, true},
+		{"version ff", "ff-" + testTraceID + "-" + testSpanID + "-01", tracectx.SpanContext{}, true},
+		{"uppercase", "00-4BF92F3577B34DA6A3CE929D0E0E4736-" + testSpanID + "-01", tracectx.SpanContext{}, true},
+		{"zero trace ID", "00-00000000000000000000000000000000-" + testSpanID + "-01", tracectx.SpanContext{}, true},
+		{"zero span ID", "00-" + testTraceID + "-0000000000000000-01", tracectx.SpanContext{}, true},
+		{"short trace ID", "00-4bf92f3577b34da6-" + testSpanID + "-01", tracectx.SpanContext{}, true},
+		{"bad flags", "00-" + testTraceID + "-" + testSpanID + "-0x", tracectx.SpanContext{}, true},
+		{"too few fields", "00-" + testTraceID + "-" + testSpanID, tracectx.SpanContext{}, true},
+		{"empty", "", tracectx.SpanContext{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tracectx.ParseTraceparent(tt.header)
+			if tt.wantErr {
+				if !errors.Is(err, tracectx.ErrInvalidTraceparent) {
+					t.Errorf("ParseTraceparent(%q) error = %v, want ErrInvalidTraceparent", tt.header, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTraceparent(%q) error = %v", tt.header, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseTraceparent(%q) = %+v, want %+v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSpanContext_Traceparent(t *testing.T) {
+	sc := tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID, Sampled: true}
+	want := "00-" + testTraceID + "-" + testSpanID + "-01"
+	if got := sc.Traceparent(); got != want {
+		t.Errorf("Traceparent() = %q, want %q", got, want)
+	}
+
+	parsed, err := tracectx.ParseTraceparent(sc.Traceparent())
+	if err != nil || parsed != sc {
+		t.Errorf("round trip = %+v, %v, want %+v", parsed, err, sc)
+	}
+
+	if got := (tracectx.SpanContext{}).Traceparent(); got != "" {
+		t.Errorf("invalid Traceparent() = %q, want empty", got)
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	if _, ok := tracectx.FromContext(context.Background()); ok {
+		t.Error("FromContext(empty) ok = true, want false")
+	}
+
+	sc := tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID}
+	ctx := tracectx.ContextWithSpan(context.Background(), sc)
+	if got, ok := tracectx.FromContext(ctx); !ok || got != sc {
+		t.Errorf("FromContext() = %+v, %v, want %+v, true", got, ok, sc)
+	}
+
+	if got := tracectx.ContextWithSpan(context.Background(), tracectx.SpanContext{TraceID: "bad"}); got != context.Background() {
+		t.Error("ContextWithSpan(invalid) returned a new context")
+	}
+}
+
+func TestFromContext_Provider(t *testing.T) {
+	t.Cleanup(func() { tracectx.SetProvider(nil) })
+
+	type spanKey struct{}
+	current := tracectx.SpanContext{TraceID: testTraceID, SpanID: "b7ad6b7169203331"}
+	tracectx.SetProvider(tracectx.ProviderFunc(func(ctx context.Context) (tracectx.SpanContext, bool) {
+		sc, ok := ctx.Value(spanKey{}).(tracectx.SpanContext)
+		return sc, ok
+	}))
+
+	inbound := tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID}
+	ctx := tracectx.ContextWithSpan(context.Background(), inbound)
+	if got, _ := tracectx.FromContext(ctx); got != inbound {
+		t.Errorf("FromContext() without tracer span = %+v, want inbound %+v", got, inbound)
+	}
+
+	ctx = context.WithValue(ctx, spanKey{}, current)
+	if got, _ := tracectx.FromContext(ctx); got != current {
+		t.Errorf("FromContext() = %+v, want provider span %+v", got, current)
+	}
+
+	tracectx.SetProvider(nil)
+	if got, _ := tracectx.FromContext(ctx); got != inbound {
+		t.Errorf("FromContext() after SetProvider(nil) = %+v, want %+v", got, inbound)
+	}
+}
+
+func TestWrapCtx(t *testing.T) {
+	sc := tracectx.SpanContext{TraceID: testTraceID, SpanID: testSpanID}
+	ctx := errx.ContextWithAttrs(tracectx.ContextWithSpan(context.Background(), sc), "request_id", "r-1")
+	cause := errors.New("no rows")
+
+	err := tracectx.WrapCtx(ctx, "load order", cause, ErrNotFound)
+	if err.Error() != "load order: no rows" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false, want true")
+	}
+	want := errx.AttrList{
+		{Key: "request_id", Value: "r-1"},
+		{Key: tracectx.TraceIDKey, Value: testTraceID},
+		{Key: tracectx.SpanIDKey, Value: testSpanID},
+	}
+	if got := errx.ExtractAttrs(err); got.String() != want.String() {
+		t.Errorf("ExtractAttrs() = %v, want %v", got, want)
+	}
+
+	// Wrapping again with the same context does not repeat the IDs
+	outer := tracectx.ClassifyCtx(ctx, tracectx.WrapCtx(ctx, "handle request", err))
+	if got := errx.ExtractAttrs(outer); got.String() != want.String() {
+		t.Errorf("ExtractAttrs(outer) = %v, want %v", got, want)
+	}
+
+	traceID, spanID := tracectx.IDs(outer)
+	if traceID != testTraceID || spanID != testSpanID {
+		t.Errorf("IDs() = %q, %q, want %q, %q", traceID, spanID, testTraceID, testSpanID)
+	}
+}
+
+func TestWrapCtx_NoSpan(t *testing.T) {
+	err := tracectx.WrapCtx(context.Background(), "load order", errors.New("no rows"))
+	if errx.HasAttrs(err) {
+		t.Errorf("ExtractAttrs() = %v, want none", errx.ExtractAttrs(err))
+	}
+	if traceID, spanID := tracectx.IDs(err); traceID != "" || spanID != "" {
+		t.Errorf("IDs() = %q, %q, want empty", traceID, spanID)
+	}
+	if tracectx.WrapCtx(context.Background(), "x", nil) != nil {
+		t.Error("WrapCtx(nil cause) != nil")
+	}
+	if tracectx.ClassifyCtx(context.Background(), nil) != nil {
+		t.Error("ClassifyCtx(nil cause) != nil")
+	}
+}